@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/file"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdJWTKey = &cobra.Command{
+	Use:   "jwt-key",
+	Short: "Generate JWT signing key pair, old public keys are kept for verification",
+	Run:   runJWTKeyGenerate,
+	Args:  cobra.NoArgs,
+}
+
+// jwt-key 命令的选项
+var (
+	jwtKeyAlgorithm string
+	jwtKeyForce     bool
+)
+
+func init() {
+	CmdJWTKey.Flags().StringVarP(&jwtKeyAlgorithm, "algorithm", "a", "", "RS256, ES256 or EdDSA, default is the jwt.algorithm config")
+	CmdJWTKey.Flags().BoolVarP(&jwtKeyForce, "force", "f", false, "overwrite the current private key (key rotation)")
+}
+
+func runJWTKeyGenerate(cmd *cobra.Command, args []string) {
+	if len(jwtKeyAlgorithm) == 0 {
+		jwtKeyAlgorithm = config.GetString("jwt.algorithm")
+	}
+
+	privatePath := config.GetString("jwt.private_key")
+	if file.Exists(privatePath) && !jwtKeyForce {
+		console.Exit(privatePath + " already exists, use --force to rotate the key")
+	}
+
+	// 1. 生成密钥对
+	var privateKey crypto.Signer
+	var err error
+	switch {
+	case strings.HasPrefix(jwtKeyAlgorithm, "RS"), strings.HasPrefix(jwtKeyAlgorithm, "PS"):
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jwtKeyAlgorithm == "ES384":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case jwtKeyAlgorithm == "ES512":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case strings.HasPrefix(jwtKeyAlgorithm, "ES"):
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwtKeyAlgorithm == "EdDSA":
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		console.Exit(fmt.Sprintf("algorithm %s does not use a key pair", jwtKeyAlgorithm))
+	}
+	console.ExitIf(err)
+
+	// 2. 以 PKCS#8 和 PKIX 格式编码
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	console.ExitIf(err)
+	publicBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	console.ExitIf(err)
+
+	// 3. 私钥写入 jwt.private_key，公钥放入验签目录，旧公钥保留以便已签发的令牌继续有效
+	publicDir := config.GetString("jwt.public_keys_path")
+	os.MkdirAll(filepath.Dir(privatePath), 0700)
+	os.MkdirAll(publicDir, 0755)
+
+	err = os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateBytes}), 0600)
+	console.ExitIf(err)
+
+	publicPath := filepath.Join(publicDir, fmt.Sprintf("%s-%s.pem", jwtKeyAlgorithm, app.TimenowInTimezone().Format("2006_01_02_150405")))
+	err = file.Put(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes}), publicPath)
+	console.ExitIf(err)
+
+	console.Success("private key: " + privatePath)
+	console.Success("public key: " + publicPath)
+	console.Warning("please set JWT_ALGORITHM=" + jwtKeyAlgorithm + " in .env and restart the server")
+}
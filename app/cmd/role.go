@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"gohub/app/models/role"
+	"gohub/app/models/user"
+	"gohub/pkg/console"
+
+	"github.com/spf13/cobra"
+)
+
+var CmdRole = &cobra.Command{
+	Use:   "role",
+	Short: "Role management",
+}
+
+var CmdRoleAssign = &cobra.Command{
+	Use:   "assign",
+	Short: "Assign a role to user, example: role assign 1 admin",
+	Run:   runRoleAssign,
+	Args:  cobra.ExactArgs(2),
+}
+
+var CmdRoleRemove = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a role from user, example: role remove 1 admin",
+	Run:   runRoleRemove,
+	Args:  cobra.ExactArgs(2),
+}
+
+func init() {
+	CmdRole.AddCommand(
+		CmdRoleAssign,
+		CmdRoleRemove,
+	)
+}
+
+func runRoleAssign(cmd *cobra.Command, args []string) {
+	userModel, roleModel := findUserAndRole(args[0], args[1])
+	if ok := roleModel.AssignTo(userModel.GetStringID()); !ok {
+		console.Exit("Failed to assign role.")
+	}
+	console.Success(fmt.Sprintf("Role [%s] assigned to user [%s].", roleModel.Name, userModel.Name))
+}
+
+func runRoleRemove(cmd *cobra.Command, args []string) {
+	userModel, roleModel := findUserAndRole(args[0], args[1])
+	roleModel.RemoveFrom(userModel.GetStringID())
+	console.Success(fmt.Sprintf("Role [%s] removed from user [%s].", roleModel.Name, userModel.Name))
+}
+
+// findUserAndRole 通过用户 ID 和角色名称获取模型，找不到时退出
+func findUserAndRole(userID, roleName string) (user.User, role.Role) {
+	userModel := user.Get(userID)
+	if userModel.ID == 0 {
+		console.Exit("User not found: " + userID)
+	}
+	roleModel := role.GetByName(roleName)
+	if roleModel.ID == 0 {
+		console.Exit("Role not found: " + roleName)
+	}
+	return userModel, roleModel
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/database"
+	"gohub/pkg/search"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var CmdSearchReindex = &cobra.Command{
+	Use:   "search:reindex",
+	Short: "Rebuild the full-text search index, example: search:reindex --type=topic",
+	Run:   runSearchReindex,
+	Args:  cobra.NoArgs,
+}
+
+// reindex 命令的选项
+var reindexType string
+
+func init() {
+	CmdSearchReindex.Flags().StringVarP(&reindexType, "type", "t", "", "Only reindex the given type: topic or user")
+}
+
+func runSearchReindex(cmd *cobra.Command, args []string) {
+	s := search.NewSearch()
+	console.ExitIf(s.Driver.Migrate(database.DB))
+
+	chunkSize := config.GetInt("search.chunk_size", 200)
+
+	if reindexType == "" || reindexType == search.TypeTopic {
+		console.ExitIf(s.Flush(nil, search.TypeTopic))
+		var topics []topic.Topic
+		var count int
+		result := database.DB.FindInBatches(&topics, chunkSize, func(tx *gorm.DB, batch int) error {
+			docs := make([]search.Document, 0, len(topics))
+			for i := range topics {
+				docs = append(docs, topics[i].SearchDocument())
+			}
+			count += len(docs)
+			return s.Index(nil, docs...)
+		})
+		console.ExitIf(result.Error)
+		console.Success(fmt.Sprintf("Indexed %d topics.", count))
+	}
+
+	if reindexType == "" || reindexType == search.TypeUser {
+		console.ExitIf(s.Flush(nil, search.TypeUser))
+		var users []user.User
+		var count int
+		result := database.DB.FindInBatches(&users, chunkSize, func(tx *gorm.DB, batch int) error {
+			docs := make([]search.Document, 0, len(users))
+			for i := range users {
+				docs = append(docs, users[i].SearchDocument())
+			}
+			count += len(docs)
+			return s.Index(nil, docs...)
+		})
+		console.ExitIf(result.Error)
+		console.Success(fmt.Sprintf("Indexed %d users.", count))
+	}
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"gohub/app/workers"
 	"gohub/bootstrap"
 	"gohub/pkg/config"
 	"gohub/pkg/console"
+	"gohub/pkg/filter"
+	"gohub/pkg/jwt"
 	"gohub/pkg/logger"
+	"gohub/pkg/realtime"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
@@ -26,12 +31,26 @@ func runWeb(cmd *cobra.Command, args []string) {
 	// 故此设置为 release，有特殊情况手动改为 debug 即可
 	gin.SetMode(gin.ReleaseMode)
 
+	// 提前加载 JWT 密钥，配置有误时直接退出
+	if _, err := jwt.LoadKeys(); err != nil {
+		console.Exit("Unable to load JWT keys, error:" + err.Error())
+	}
+
 	// gin 实例
 	router := gin.New()
 
 	// 初始化路由绑定
 	bootstrap.SetupRoute(router)
 
+	// 接收其他实例（或本实例）发布的实时事件，推送给本机的 WebSocket 和 SSE 连接
+	go realtime.NewHub().Run(context.Background())
+
+	// 定时重新加载敏感词词表，修改词表后无需重启服务
+	go filter.NewFilter().Run(context.Background())
+
+	// 定时发布到期的话题
+	go workers.RunTopicPublisher(context.Background())
+
 	// 运行服务器
 	err := router.Run(":" + config.Get("app.port"))
 	if err != nil {
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSController 对外公开 JWT 验签公钥
+type JWKSController struct {
+	v1.BaseAPIController
+}
+
+// Show 输出 JSON Web Key Set，供其他服务验证本站签发的令牌
+func (jc *JWKSController) Show(c *gin.Context) {
+	keys, err := jwt.LoadKeys()
+	if err != nil {
+		response.Abort500(c)
+		return
+	}
+	// 公钥变动不频繁，允许客户端缓存
+	c.Header("Cache-Control", "public, max-age=3600")
+	response.JSON(c, keys.JWKS())
+}
@@ -1,13 +1,21 @@
 package auth
 
 import (
+	"fmt"
 	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/models/user"
 	"gohub/app/requests"
 	"gohub/pkg/auth"
+	"gohub/pkg/captcha"
 	"gohub/pkg/jwt"
+	"gohub/pkg/lockout"
 	"gohub/pkg/response"
+	"gohub/pkg/session"
+	"gohub/pkg/twofactor"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 )
 
 // LoginController 用户控制器
@@ -30,10 +38,7 @@ func (lc *LoginController) LoginByPhone(c *gin.Context) {
 		response.Error(c, err, "账号不存在或密码错误")
 	} else {
 		// 登录成功
-		token := jwt.NewJWT().IssueToken(user.GetStringID(), user.Name)
-		response.JSON(c, gin.H{
-			"token": token,
-		})
+		respondTokens(c, user, request.DeviceName)
 	}
 }
 
@@ -44,27 +49,134 @@ func (lc *LoginController) LoginByPassword(c *gin.Context) {
 		return
 	}
 
-	// 2. 尝试登录
+	// 2. 账号已被锁定时直接拒绝，失败次数较多时需要图片验证码
+	lo := lockout.NewLockout()
+	key := auth.LockoutKey(request.LoginID)
+	status := lo.Status(key)
+	if status.Locked {
+		abortLoginFailed(c, status, "")
+		return
+	}
+	if status.CaptchaRequired && !captcha.NewCaptcha().VerifyCaptcha(request.CaptchaID, request.CaptchaAnswer) {
+		response.AbortJSON(c, http.StatusUnprocessableEntity, gin.H{
+			"message": "请求验证不通过，具体请查看 errors",
+			"errors":  map[string][]string{"captcha_answer": {"图片验证码错误"}},
+			"lockout": status,
+		})
+		return
+	}
+
+	// 3. 尝试登录
 	userModel, err := auth.Attempt(request.LoginID, request.Password)
 	if err != nil {
-		// 失败，显示错误提示
-		response.Unauthorized(c, "登录失败")
+		// 失败，记录失败次数并显示错误提示
+		abortLoginFailed(c, lo.Fail(key), "登录失败")
+	} else if userModel.HasTwoFactorEnabled() {
+		// 已开启两步验证，需使用挑战令牌和验证码完成登录
+		respondTwoFactorChallenge(c, userModel, request.DeviceName)
 	} else {
-		token := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
-		response.JSON(c, gin.H{
-			"token": token,
-		})
+		lo.Clear(key)
+		respondTokens(c, userModel, request.DeviceName)
 	}
 }
 
-// RefreshToken 刷新 Access Token
+// LoginByTwoFactor 两步验证登录的第二步，使用挑战令牌和验证码（或恢复码）换取令牌
+func (lc *LoginController) LoginByTwoFactor(c *gin.Context) {
+	request := requests.LoginByTwoFactorRequest{}
+	if ok := requests.Validate(c, &request, requests.LoginByTwoFactor); !ok {
+		return
+	}
+
+	tf := twofactor.NewTwoFactor()
+	challenge, ok := tf.GetChallenge(request.ChallengeToken)
+	if !ok {
+		response.Unauthorized(c, "登录已超时，请重新输入密码")
+		return
+	}
+
+	userModel := user.Get(challenge.UserID)
+	if userModel.ID == 0 || !userModel.HasTwoFactorEnabled() {
+		tf.CompleteChallenge(request.ChallengeToken)
+		response.Unauthorized(c, "登录失败")
+		return
+	}
+
+	// 验证码输错同样计入账号的失败次数
+	lo := lockout.NewLockout()
+	key := auth.UserLockoutKey(userModel)
+	if status := lo.Status(key); status.Locked {
+		tf.CompleteChallenge(request.ChallengeToken)
+		abortLoginFailed(c, status, "")
+		return
+	}
+	if !tf.Verify(&userModel, request.Code) {
+		tf.FailChallenge(request.ChallengeToken)
+		abortLoginFailed(c, lo.Fail(key), "验证码或恢复码错误")
+		return
+	}
+
+	lo.Clear(key)
+	tf.CompleteChallenge(request.ChallengeToken)
+	respondTokens(c, userModel, challenge.DeviceName)
+}
+
+// RefreshToken 使用 Refresh Token 换取新的令牌对，旧的 Refresh Token 随即失效
 func (lc *LoginController) RefreshToken(c *gin.Context) {
-	token, err := jwt.NewJWT().RefreshToken(c)
-	if err != nil {
+	tokens, err := jwt.NewJWT().RefreshToken(c)
+	if err == jwt.ErrStoreUnavailable {
+		response.Abort500(c, "令牌刷新失败, 请稍后尝试~")
+	} else if err != nil {
 		response.Error(c, err, "令牌刷新失败")
 	} else {
-		response.JSON(c, gin.H{
-			"token": token,
-		})
+		response.JSON(c, tokens)
+	}
+}
+
+// Logout 退出登录，注销当前会话
+func (lc *LoginController) Logout(c *gin.Context) {
+	if ok := session.NewRegistry().Revoke(auth.CurrentUID(c), auth.CurrentSessionID(c)); !ok {
+		response.Abort500(c, "退出登录失败, 请稍后尝试~")
+		return
+	}
+	response.Success(c)
+}
+
+// LogoutAll 退出所有设备，注销当前用户的所有会话
+func (lc *LoginController) LogoutAll(c *gin.Context) {
+	count := session.NewRegistry().RevokeAll(auth.CurrentUID(c))
+	response.JSON(c, gin.H{
+		"success": true,
+		"revoked": count,
+	})
+}
+
+// respondTokens 登录成功，签发令牌并登记会话
+func respondTokens(c *gin.Context, userModel user.User, deviceName string) {
+	tokens := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+	session.NewRegistry().Register(c, userModel.GetStringID(), tokens.FamilyID, deviceName)
+	response.JSON(c, tokens)
+}
+
+// respondTwoFactorChallenge 用户已开启两步验证，返回挑战令牌，需调用 /auth/login/two-factor 完成登录
+func respondTwoFactorChallenge(c *gin.Context, userModel user.User, deviceName string) {
+	token := twofactor.NewTwoFactor().CreateChallenge(userModel.GetStringID(), deviceName)
+	response.JSON(c, gin.H{
+		"two_factor_required": true,
+		"challenge_token":     token,
+		"expires_in":          int64(twofactor.ChallengeExpireTime().Seconds()),
+	})
+}
+
+// abortLoginFailed 登录失败的响应，统一附带账号的锁定状态 lockout，账号被锁定时响应 429
+func abortLoginFailed(c *gin.Context, status lockout.Status, msg string) {
+	code := http.StatusUnauthorized
+	if status.Locked {
+		code = http.StatusTooManyRequests
+		msg = fmt.Sprintf("登录失败次数过多，请 %d 秒后重试，或使用手机/邮箱验证码解锁", status.RetryAfter)
+		c.Header("Retry-After", cast.ToString(status.RetryAfter))
 	}
+	response.AbortJSON(c, code, gin.H{
+		"message": msg,
+		"lockout": status,
+	})
 }
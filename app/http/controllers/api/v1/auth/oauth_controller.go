@@ -0,0 +1,198 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/models/user"
+	"gohub/app/models/user_identity"
+	"gohub/app/requests"
+	"gohub/pkg/app"
+	"gohub/pkg/helpers"
+	"gohub/pkg/oauth"
+	"gohub/pkg/response"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OAuthController 第三方登录
+type OAuthController struct {
+	v1.BaseAPIController
+}
+
+// Providers 已启用的第三方登录平台
+func (oc *OAuthController) Providers(c *gin.Context) {
+	response.Data(c, oauth.Names())
+}
+
+// Redirect 获取第三方平台的授权页链接，前端跳转到该链接
+func (oc *OAuthController) Redirect(c *gin.Context) {
+	request := requests.OAuthRedirectRequest{}
+	if ok := requests.Validate(c, &request, requests.OAuthRedirect); !ok {
+		return
+	}
+
+	provider, ok := oauth.Get(c.Param("provider"))
+	if !ok {
+		response.Abort404(c, "不支持该第三方登录方式")
+		return
+	}
+
+	url := oauth.NewOAuth().Begin(provider, oauth.State{
+		Action:     oauth.ActionLogin,
+		DeviceName: request.DeviceName,
+	})
+	if len(url) == 0 {
+		response.Abort500(c, "第三方平台暂时无法访问, 请稍后尝试~")
+		return
+	}
+	response.JSON(c, gin.H{
+		"url": url,
+	})
+}
+
+// Callback 第三方平台授权后的回调，登录（首次登录时自动注册）或绑定到发起绑定的账号
+func (oc *OAuthController) Callback(c *gin.Context) {
+	request := requests.OAuthCallbackRequest{}
+	if ok := requests.Validate(c, &request, requests.OAuthCallback); !ok {
+		return
+	}
+
+	identity, st, err := oauth.NewOAuth().Complete(c.Param("provider"), request.State, request.Code)
+	if err != nil {
+		if err == oauth.ErrInvalidState {
+			response.Unauthorized(c, "授权已过期，请重新发起")
+		} else {
+			response.Unauthorized(c, "第三方登录失败，请稍后尝试~")
+		}
+		return
+	}
+
+	if st.Action == oauth.ActionLink {
+		linkIdentity(c, st.UserID, identity)
+		return
+	}
+
+	// 1. 已绑定的第三方账号，直接登录
+	userIdentity := user_identity.GetBySubject(identity.Provider, identity.Subject)
+	if userIdentity.ID > 0 {
+		userModel := user.Get(userIdentity.UserID)
+		if userModel.ID == 0 {
+			response.Abort404(c, "绑定的用户不存在")
+			return
+		}
+		loginByIdentity(c, userModel, st.DeviceName)
+		return
+	}
+
+	// 2. 邮箱已被注册时不自动绑定，避免第三方平台的邮箱被冒用
+	if len(identity.Email) > 0 && user.IsEmailExist(identity.Email) {
+		response.Abort403(c, "该邮箱已注册，请先登录，再到账号设置中绑定第三方账号")
+		return
+	}
+
+	// 3. 首次登录，注册新用户
+	userModel := user.User{
+		Name:     uniqueUserName(identity.Name),
+		Avatar:   identity.Avatar,
+		Password: uuid.New().String(),
+	}
+	if identity.EmailVerified {
+		verifiedAt := app.TimenowInTimezone()
+		userModel.Email = identity.Email
+		userModel.EmailVerifiedAt = &verifiedAt
+	}
+	userModel.Create()
+	if userModel.ID == 0 {
+		response.Abort500(c, "创建用户失败, 请稍后尝试~")
+		return
+	}
+	if userIdentity = createIdentity(userModel.GetStringID(), identity); userIdentity.ID == 0 {
+		response.Abort500(c, "绑定第三方账号失败, 请稍后尝试~")
+		return
+	}
+	loginByIdentity(c, userModel, st.DeviceName)
+}
+
+// FakeAuthorize 本地模拟平台的授权页，直接以 login 参数指定的身份同意授权并跳转回回调地址
+func (oc *OAuthController) FakeAuthorize(c *gin.Context) {
+	provider, ok := oauth.Get("fake")
+	if !ok {
+		response.Abort404(c)
+		return
+	}
+
+	redirect, err := provider.(*oauth.Fake).Authorize(c.Request.URL.Query(), c.Query("login"))
+	if err != nil {
+		response.BadRequest(c, err, err.Error())
+		return
+	}
+	c.Redirect(http.StatusFound, redirect)
+}
+
+// loginByIdentity 第三方登录成功，开启了两步验证的用户仍需输入验证码
+func loginByIdentity(c *gin.Context, userModel user.User, deviceName string) {
+	if userModel.HasTwoFactorEnabled() {
+		respondTwoFactorChallenge(c, userModel, deviceName)
+	} else {
+		respondTokens(c, userModel, deviceName)
+	}
+}
+
+// linkIdentity 将第三方账号绑定到发起绑定的用户
+func linkIdentity(c *gin.Context, userID string, identity oauth.Identity) {
+	userIdentity := user_identity.GetBySubject(identity.Provider, identity.Subject)
+	if userIdentity.ID > 0 {
+		if userIdentity.UserID != userID {
+			response.Abort403(c, "该第三方账号已绑定其他用户")
+		} else {
+			response.Data(c, userIdentity)
+		}
+		return
+	}
+	if user_identity.GetByUser(userID, identity.Provider).ID > 0 {
+		response.Abort403(c, "已绑定该平台的其他账号，请先解绑")
+		return
+	}
+
+	if userIdentity = createIdentity(userID, identity); userIdentity.ID == 0 {
+		response.Abort500(c, "绑定第三方账号失败, 请稍后尝试~")
+		return
+	}
+	response.Created(c, userIdentity)
+}
+
+// createIdentity 保存用户绑定的第三方账号
+func createIdentity(userID string, identity oauth.Identity) user_identity.UserIdentity {
+	userIdentity := user_identity.UserIdentity{
+		UserID:   userID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Name:     identity.Name,
+		Email:    identity.Email,
+		Avatar:   identity.Avatar,
+	}
+	userIdentity.Create()
+	return userIdentity
+}
+
+// nonAlphaNum 用户名只允许数字和英文
+var nonAlphaNum = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// uniqueUserName 根据第三方账号的昵称生成可用的用户名，与注册时的规则一致（3~20 位数字和英文）
+func uniqueUserName(name string) string {
+	name = nonAlphaNum.ReplaceAllString(name, "")
+	if len(name) > 14 {
+		name = name[:14]
+	}
+	if len(name) < 3 {
+		name = "user" + name
+	}
+
+	candidate := name
+	for user.IsNameExist(candidate) {
+		candidate = name + helpers.RandomNumber(6)
+	}
+	return candidate
+}
@@ -4,6 +4,8 @@ import (
 	v1 "gohub/app/http/controllers/api/v1"
 	"gohub/app/models/user"
 	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/lockout"
 	"gohub/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +31,8 @@ func (pc *PasswordController) ResetByPhone(c *gin.Context) {
 	} else {
 		userModel.Password = request.Password
 		userModel.Save()
+		// 重置密码后解除登录锁定
+		lockout.NewLockout().Clear(auth.UserLockoutKey(userModel))
 
 		response.Success(c)
 	}
@@ -49,6 +53,8 @@ func (pc *PasswordController) ResetByEmail(c *gin.Context) {
 	} else {
 		userModel.Password = requestData.Password
 		userModel.Save()
+		// 重置密码后解除登录锁定
+		lockout.NewLockout().Clear(auth.UserLockoutKey(userModel))
 		response.Success(c)
 	}
 }
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+	"gohub/pkg/session"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionsController 登录会话控制器
+type SessionsController struct {
+	v1.BaseAPIController
+}
+
+// Index 当前用户所有已登录的设备
+func (sc *SessionsController) Index(c *gin.Context) {
+	currentID := auth.CurrentSessionID(c)
+	sessions := session.NewRegistry().List(auth.CurrentUID(c))
+
+	data := make([]gin.H, 0, len(sessions))
+	for _, sess := range sessions {
+		data = append(data, gin.H{
+			"id":           sess.ID,
+			"device_name":  sess.DeviceName,
+			"ip":           sess.IP,
+			"user_agent":   sess.UserAgent,
+			"issued_at":    sess.IssuedAt,
+			"last_seen_at": sess.LastSeenAt,
+			"expires_at":   sess.ExpiresAt,
+			"current":      sess.ID == currentID,
+		})
+	}
+	response.Data(c, data)
+}
+
+// Delete 注销指定设备上的登录
+func (sc *SessionsController) Delete(c *gin.Context) {
+	if ok := session.NewRegistry().Revoke(auth.CurrentUID(c), c.Param("id")); !ok {
+		response.Abort404(c, "登录会话不存在或已失效")
+		return
+	}
+	response.Success(c)
+}
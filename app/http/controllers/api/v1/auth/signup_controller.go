@@ -4,8 +4,10 @@ import (
 	v1 "gohub/app/http/controllers/api/v1"
 	"gohub/app/models/user"
 	"gohub/app/requests"
+	"gohub/pkg/app"
 	"gohub/pkg/jwt"
 	"gohub/pkg/response"
+	"gohub/pkg/session"
 
 	"github.com/gin-gonic/gin"
 )
@@ -50,18 +52,23 @@ func (s *SignupController) SignupUsingPhone(c *gin.Context) {
 	}
 
 	// 2. 验证成功，创建数据
+	// 手机号已通过验证码确认
+	verifiedAt := app.TimenowInTimezone()
 	userModel := user.User{
-		Name:     request.Name,
-		Phone:    request.Phone,
-		Password: request.Password,
+		Name:            request.Name,
+		Phone:           request.Phone,
+		Password:        request.Password,
+		PhoneVerifiedAt: &verifiedAt,
 	}
 	userModel.Create()
 
 	if userModel.ID > 0 {
-		token := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+		tokens := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+		session.NewRegistry().Register(c, userModel.GetStringID(), tokens.FamilyID, "")
 		response.CreatedJSON(c, gin.H{
-			"data":  userModel,
-			"token": token,
+			"data":          userModel,
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
 		})
 	} else {
 		response.Abort500(c, "创建用户失败, 请稍后尝试~")
@@ -77,18 +84,23 @@ func (s *SignupController) SignupUsingEmail(c *gin.Context) {
 	}
 
 	// 2. 验证成功，创建数据
+	// Email 已通过验证码确认
+	verifiedAt := app.TimenowInTimezone()
 	userModel := user.User{
-		Name:     request.Name,
-		Email:    request.Email,
-		Password: request.Password,
+		Name:            request.Name,
+		Email:           request.Email,
+		Password:        request.Password,
+		EmailVerifiedAt: &verifiedAt,
 	}
 	userModel.Create()
 
 	if userModel.ID > 0 {
-		token := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+		tokens := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+		session.NewRegistry().Register(c, userModel.GetStringID(), tokens.FamilyID, "")
 		response.CreatedJSON(c, gin.H{
-			"data":  userModel,
-			"token": token,
+			"data":          userModel,
+			"token":         tokens.AccessToken,
+			"refresh_token": tokens.RefreshToken,
 		})
 	} else {
 		response.Abort500(c, "创建用户失败, 请稍后尝试~")
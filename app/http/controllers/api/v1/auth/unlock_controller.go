@@ -0,0 +1,53 @@
+package auth
+
+import (
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/lockout"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnlockController 解除因登录失败次数过多导致的账号锁定
+type UnlockController struct {
+	v1.BaseAPIController
+}
+
+// UnlockByPhone 使用手机和验证码解锁
+func (uc *UnlockController) UnlockByPhone(c *gin.Context) {
+	// 1. 验证表单
+	request := requests.UnlockByPhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.UnlockByPhone); !ok {
+		return
+	}
+
+	// 2. 清除失败记录
+	userModel := user.GetByPhone(request.Phone)
+	if userModel.ID == 0 {
+		response.Abort404(c)
+	} else {
+		lockout.NewLockout().Clear(auth.UserLockoutKey(userModel))
+		response.Success(c)
+	}
+}
+
+// UnlockByEmail 使用 Email 和验证码解锁
+func (uc *UnlockController) UnlockByEmail(c *gin.Context) {
+	// 1. 验证表单
+	request := requests.UnlockByEmailRequest{}
+	if ok := requests.Validate(c, &request, requests.UnlockByEmail); !ok {
+		return
+	}
+
+	// 2. 清除失败记录
+	userModel := user.GetByEmail(request.Email)
+	if userModel.ID == 0 {
+		response.Abort404(c)
+	} else {
+		lockout.NewLockout().Clear(auth.UserLockoutKey(userModel))
+		response.Success(c)
+	}
+}
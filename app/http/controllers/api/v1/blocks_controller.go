@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"gohub/app/models/block"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlocksController struct {
+	BaseAPIController
+}
+
+// Index 当前用户的黑名单
+func (ctrl *BlocksController) Index(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := block.PaginateByUser(c, auth.CurrentUID(c), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Store 屏蔽用户，屏蔽后双方都不能给对方发私信，重复屏蔽结果不变
+func (ctrl *BlocksController) Store(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if userModel.GetStringID() == auth.CurrentUID(c) {
+		response.Abort403(c, "不能屏蔽自己")
+		return
+	}
+
+	if err := block.Add(auth.CurrentUID(c), userModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "屏蔽失败，请稍后尝试~")
+		return
+	}
+	response.Data(c, gin.H{"blocked": true})
+}
+
+// Delete 取消屏蔽，未屏蔽时同样返回成功
+func (ctrl *BlocksController) Delete(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if err := block.Remove(auth.CurrentUID(c), userModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "取消屏蔽失败，请稍后尝试~")
+		return
+	}
+	response.Data(c, gin.H{"blocked": false})
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"gohub/app/models/category"
+	"gohub/app/models/topic"
 	"gohub/app/requests"
 	"gohub/pkg/response"
 
@@ -25,6 +26,32 @@ func (ctrl *CategoriesController) Index(c *gin.Context) {
 	})
 }
 
+// Topics 分类下的话题，过滤和排序参数与话题列表相同
+func (ctrl *CategoriesController) Topics(c *gin.Context) {
+	categoryModel := category.Get(c.Param("id"))
+	if categoryModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.TopicFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicFilter); !ok {
+		return
+	}
+
+	if _, ok := c.GetQuery("cursor"); ok {
+		data, pager, err := topic.CursorPaginateByCategory(c, categoryModel.GetStringID(), 10)
+		respondTopicsByCursor(c, data, pager, err)
+		return
+	}
+
+	data, pager := topic.PaginateByCategory(c, categoryModel.GetStringID(), 10)
+	response.JSON(c, gin.H{
+		"data":  topic.Summaries(data),
+		"pager": pager,
+	})
+}
+
 func (ctrl *CategoriesController) Store(c *gin.Context) {
 
 	request := requests.CategoryRequest{}
@@ -68,6 +95,7 @@ func (ctrl *CategoriesController) Update(c *gin.Context) {
 	}
 }
 
+// Delete 将分类移入回收站，分类下还有未删除的话题时不允许删除，避免话题失去分类后仍出现在列表中
 func (ctrl *CategoriesController) Delete(c *gin.Context) {
 
 	categoryModel := category.Get(c.Param("id"))
@@ -76,6 +104,11 @@ func (ctrl *CategoriesController) Delete(c *gin.Context) {
 		return
 	}
 
+	if topic.HasUntrashedInCategory(categoryModel.GetStringID()) {
+		response.Abort403(c, "分类下还有话题，请先移动或删除这些话题")
+		return
+	}
+
 	rowsAffected := categoryModel.Delete()
 	if rowsAffected > 0 {
 		response.Success(c)
@@ -84,3 +117,58 @@ func (ctrl *CategoriesController) Delete(c *gin.Context) {
 
 	response.Abort500(c, "删除失败, 请稍后尝试~")
 }
+
+// Trashed 回收站中的分类
+func (ctrl *CategoriesController) Trashed(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := category.PaginateTrashed(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Restore 从回收站恢复分类，恢复后可以重新在该分类下发布话题
+func (ctrl *CategoriesController) Restore(c *gin.Context) {
+
+	categoryModel := category.GetTrashed(c.Param("id"))
+	if categoryModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := categoryModel.Restore()
+	if rowsAffected > 0 {
+		response.Data(c, category.Get(categoryModel.GetStringID()))
+		return
+	}
+
+	response.Abort500(c, "恢复失败, 请稍后尝试~")
+}
+
+// ForceDelete 彻底删除回收站中的分类，分类下还有话题时不允许删除，避免话题成为孤儿数据
+func (ctrl *CategoriesController) ForceDelete(c *gin.Context) {
+
+	categoryModel := category.GetTrashed(c.Param("id"))
+	if categoryModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if topic.HasInCategory(categoryModel.GetStringID()) {
+		response.Abort403(c, "分类下还有话题（包括回收站中的话题），请先移动或彻底删除这些话题")
+		return
+	}
+
+	rowsAffected := categoryModel.ForceDelete()
+	if rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
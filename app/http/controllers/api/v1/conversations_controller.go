@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"gohub/app/models/block"
+	"gohub/app/models/conversation"
+	"gohub/app/models/message"
+	"gohub/app/notifications"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/realtime"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type ConversationsController struct {
+	BaseAPIController
+}
+
+// Index 当前用户的私信会话，?unread=1 时只返回有未读消息的会话，如 ?sort=-last_message_at
+func (ctrl *ConversationsController) Index(c *gin.Context) {
+	request := requests.ConversationFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.ConversationFilter); !ok {
+		return
+	}
+
+	data, pager := conversation.Paginate(c, auth.CurrentUID(c), cast.ToBool(request.Unread), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// UnreadCount 当前用户所有会话的未读消息数
+func (ctrl *ConversationsController) UnreadCount(c *gin.Context) {
+	response.Data(c, gin.H{
+		"unread_count": conversation.UnreadCount(auth.CurrentUID(c)),
+	})
+}
+
+// Store 开始与某个用户的会话，已有会话时返回已有的会话
+func (ctrl *ConversationsController) Store(c *gin.Context) {
+	request := requests.ConversationRequest{}
+	if ok := requests.Validate(c, &request, requests.ConversationStart); !ok {
+		return
+	}
+	if ok := canMessage(c, request.UserID); !ok {
+		return
+	}
+
+	conversationModel, err := conversation.Start(auth.CurrentUID(c), request.UserID)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "创建会话失败，请稍后尝试~")
+		return
+	}
+	response.Data(c, conversationModel)
+}
+
+func (ctrl *ConversationsController) Show(c *gin.Context) {
+	conversationModel := conversation.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if conversationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	response.Data(c, conversationModel)
+}
+
+// Messages 会话中的消息
+func (ctrl *ConversationsController) Messages(c *gin.Context) {
+	conversationModel := conversation.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if conversationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := message.PaginateBetween(
+		c,
+		auth.CurrentUID(c),
+		conversationModel.PeerID,
+		"conversations/"+conversationModel.GetStringID()+"/messages",
+		20,
+	)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// StoreMessage 在会话中发送私信，并通知对方
+func (ctrl *ConversationsController) StoreMessage(c *gin.Context) {
+	conversationModel := conversation.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if conversationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if ok := canMessage(c, conversationModel.PeerID); !ok {
+		return
+	}
+
+	request := requests.MessageRequest{}
+	if ok := requests.Validate(c, &request, requests.MessageSave); !ok {
+		return
+	}
+
+	messageModel := message.Message{
+		SenderID:    auth.CurrentUID(c),
+		RecipientID: conversationModel.PeerID,
+		Body:        request.Body,
+	}
+	messageModel.Create()
+	if messageModel.ID == 0 {
+		response.Abort500(c, "发送失败，请稍后尝试~")
+		return
+	}
+
+	messageModel = message.Get(messageModel.GetStringID())
+	received := conversation.GetByPeer(messageModel.RecipientID, messageModel.SenderID)
+	notifications.Send(messageModel.RecipientID, notifications.MessageReceived{
+		ConversationID: received.GetStringID(),
+		Message:        messageModel,
+		Sender:         messageModel.Sender,
+	})
+	// 对方在线时实时收到私信，不受通知渠道设置的影响
+	logger.LogIf(realtime.NewHub().Publish(realtime.UserChannel(messageModel.RecipientID), "message.created", gin.H{
+		"conversation_id": received.GetStringID(),
+		"message":         messageModel,
+	}))
+	response.Created(c, messageModel)
+}
+
+// MarkAsRead 将会话中对方发来的消息全部标记为已读
+func (ctrl *ConversationsController) MarkAsRead(c *gin.Context) {
+	conversationModel := conversation.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if conversationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	conversationModel.MarkAsRead()
+	response.Data(c, conversationModel)
+}
+
+// canMessage 当前用户能否给 userID 发私信，不能时响应 403
+func canMessage(c *gin.Context, userID string) bool {
+	switch {
+	case userID == auth.CurrentUID(c):
+		response.Abort403(c, "不能给自己发私信")
+	case block.IsBlocked(userID, auth.CurrentUID(c)):
+		response.Abort403(c, "对方已将你加入黑名单")
+	case block.IsBlocked(auth.CurrentUID(c), userID):
+		response.Abort403(c, "你已将对方加入黑名单，取消屏蔽后才能发私信")
+	default:
+		return true
+	}
+	return false
+}
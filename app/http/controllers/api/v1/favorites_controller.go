@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"gohub/app/models/favorite"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FavoritesController struct {
+	BaseAPIController
+}
+
+// Index 当前用户收藏的话题
+func (ctrl *FavoritesController) Index(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := favorite.PaginateByUser(c, auth.CurrentUID(c), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Store 收藏话题，重复收藏结果不变
+func (ctrl *FavoritesController) Store(c *gin.Context) {
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if err := favorite.Add(auth.CurrentUID(c), topicModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "收藏失败，请稍后尝试~")
+		return
+	}
+	respondFavorite(c, topicModel.GetStringID())
+}
+
+// Delete 取消收藏，未收藏时同样返回成功
+func (ctrl *FavoritesController) Delete(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if err := favorite.Remove(auth.CurrentUID(c), topicModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "取消收藏失败，请稍后尝试~")
+		return
+	}
+	respondFavorite(c, topicModel.GetStringID())
+}
+
+// respondFavorite 返回当前用户是否收藏和话题最新的收藏数
+func respondFavorite(c *gin.Context, topicID string) {
+	response.Data(c, gin.H{
+		"favorited":      favorite.IsFavorited(auth.CurrentUID(c), topicID),
+		"favorite_count": topic.Get(topicID).FavoriteCount,
+	})
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"gohub/app/models/follow"
+	"gohub/app/models/reply"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/feed"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
+	"gohub/pkg/response"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type FeedController struct {
+	BaseAPIController
+}
+
+// Index 当前用户的时间线，关注的用户发布的话题和回复，按时间倒序
+// 使用游标分页，下一页传参上一页返回的 next_cursor，如 ?cursor=1792300000000000
+func (ctrl *FeedController) Index(c *gin.Context) {
+	request := requests.FeedRequest{}
+	if ok := requests.Validate(c, &request, requests.Feed); !ok {
+		return
+	}
+
+	perPage := cast.ToInt(request.PerPage)
+	if perPage <= 0 {
+		perPage = config.GetInt("feed.perpage")
+	}
+	activities, next, err := follow.Timeline(auth.CurrentUID(c), cast.ToInt64(request.Cursor), perPage)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "动态服务暂不可用, 请稍后尝试~")
+		return
+	}
+
+	pager := paginator.CursorPage{PerPage: perPage}
+	if next > 0 {
+		pager.NextCursor = cast.ToString(next)
+	}
+	response.JSON(c, gin.H{
+		"data":  hydrateActivities(activities),
+		"pager": pager,
+	})
+}
+
+// hydrateActivities 读取动态的作者、话题和回复，内容已删除的动态不再显示
+func hydrateActivities(activities []feed.Activity) []gin.H {
+	var userIDs, topicIDs, replyIDs []string
+	for _, activity := range activities {
+		userIDs = append(userIDs, activity.ActorID)
+		topicIDs = append(topicIDs, activity.TopicID)
+		if activity.Verb == feed.VerbReplyCreated {
+			replyIDs = append(replyIDs, activity.ObjectID)
+		}
+	}
+
+	users := make(map[string]user.User)
+	for _, u := range user.GetByIDs(userIDs) {
+		users[u.GetStringID()] = u
+	}
+	topics := make(map[string]topic.Topic)
+	for _, t := range topic.GetByIDs(topicIDs) {
+		topics[t.GetStringID()] = t
+	}
+	replies := make(map[string]reply.Reply)
+	for _, r := range reply.GetByIDs(replyIDs) {
+		replies[r.GetStringID()] = r
+	}
+
+	items := []gin.H{}
+	for _, activity := range activities {
+		actor, ok := users[activity.ActorID]
+		if !ok {
+			continue
+		}
+		topicModel, ok := topics[activity.TopicID]
+		if !ok {
+			continue
+		}
+		item := gin.H{
+			"verb":       activity.Verb,
+			"actor":      actor,
+			"topic":      topicModel,
+			"created_at": time.UnixMicro(activity.Time),
+		}
+		if activity.Verb == feed.VerbReplyCreated {
+			replyModel, ok := replies[activity.ObjectID]
+			if !ok {
+				continue
+			}
+			item["reply"] = replyModel
+		}
+		items = append(items, item)
+	}
+	return items
+}
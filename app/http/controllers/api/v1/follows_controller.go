@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"gohub/app/models/follow"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowsController struct {
+	BaseAPIController
+}
+
+// Followers 用户的粉丝
+func (ctrl *FollowsController) Followers(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := follow.PaginateFollowers(c, userModel.GetStringID(), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Followings 用户关注的用户
+func (ctrl *FollowsController) Followings(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := follow.PaginateFollowings(c, userModel.GetStringID(), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Store 关注用户，重复关注结果不变
+func (ctrl *FollowsController) Store(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if userModel.GetStringID() == auth.CurrentUID(c) {
+		response.Abort403(c, "不能关注自己")
+		return
+	}
+
+	if err := follow.Add(auth.CurrentUID(c), userModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "关注失败，请稍后尝试~")
+		return
+	}
+	respondFollow(c, userModel.GetStringID())
+}
+
+// Delete 取消关注，未关注时同样返回成功
+func (ctrl *FollowsController) Delete(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if err := follow.Remove(auth.CurrentUID(c), userModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "取消关注失败，请稍后尝试~")
+		return
+	}
+	respondFollow(c, userModel.GetStringID())
+}
+
+// respondFollow 返回当前用户是否关注和对方最新的粉丝数
+func respondFollow(c *gin.Context, userID string) {
+	response.Data(c, gin.H{
+		"following":       follow.IsFollowing(auth.CurrentUID(c), userID),
+		"followers_count": user.Get(userID).FollowersCount,
+	})
+}
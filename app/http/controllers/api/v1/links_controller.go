@@ -2,6 +2,7 @@ package v1
 
 import (
 	"gohub/app/models/link"
+	"gohub/app/requests"
 	"gohub/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,76 @@ func (ctrl *LinksController) Index(c *gin.Context) {
 	response.Data(c, link.AllCached())
 }
 
+// Delete 删除链接，移入回收站
+func (ctrl *LinksController) Delete(c *gin.Context) {
+
+	linkModel := link.Get(c.Param("id"))
+	if linkModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := linkModel.Delete()
+	if rowsAffected > 0 {
+		link.ForgetCached()
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
+
+// Trashed 回收站中的链接
+func (ctrl *LinksController) Trashed(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := link.PaginateTrashed(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Restore 从回收站恢复链接
+func (ctrl *LinksController) Restore(c *gin.Context) {
+
+	linkModel := link.GetTrashed(c.Param("id"))
+	if linkModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := linkModel.Restore()
+	if rowsAffected > 0 {
+		link.ForgetCached()
+		response.Data(c, link.Get(linkModel.GetStringID()))
+		return
+	}
+
+	response.Abort500(c, "恢复失败, 请稍后尝试~")
+}
+
+// ForceDelete 彻底删除回收站中的链接
+func (ctrl *LinksController) ForceDelete(c *gin.Context) {
+
+	linkModel := link.GetTrashed(c.Param("id"))
+	if linkModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := linkModel.ForceDelete()
+	if rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
+
 // func (ctrl *LinksController) Show(c *gin.Context) {
 //   linkModel := link.Get(c.Param("id"))
 //   if linkModel.ID == 0 {
@@ -73,25 +144,3 @@ func (ctrl *LinksController) Index(c *gin.Context) {
 //     response.Abort500(c, "更新失败，请稍后尝试~")
 //   }
 // }
-
-// func (ctrl *LinksController) Delete(c *gin.Context) {
-
-//   linkModel := link.Get(c.Param("id"))
-//   if linkModel.ID == 0 {
-//     response.Abort404(c)
-//     return
-//   }
-
-//   if ok := policies.CanModifyLink(c, linkModel); !ok {
-//     response.Abort403(c)
-//     return
-//   }
-
-//   rowsAffected := linkModel.Delete()
-//   if rowsAffected > 0 {
-//     response.Success(c)
-//     return
-//   }
-
-//   response.Abort500(c, "删除失败，请稍后尝试~")
-// }
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"gohub/app/models/moderation_log"
+	"gohub/app/models/report"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationController 版主处理举报，需要 report.moderate 权限
+// 处理的对象是被举报的话题：处理某条举报时，同一话题所有待处理的举报一并处理，每次处理都记录到处理记录中
+type ModerationController struct {
+	BaseAPIController
+}
+
+// Reports 举报列表，待处理队列即 ?status=pending，默认最早的举报排在前面
+func (ctrl *ModerationController) Reports(c *gin.Context) {
+	request := requests.ReportFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.ReportFilter); !ok {
+		return
+	}
+
+	data, pager := report.Paginate(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Hide 举报成立，隐藏话题（移入回收站），作者不能再查看，拥有 topic.restore 权限的版主和管理员可在回收站中查看和恢复
+func (ctrl *ModerationController) Hide(c *gin.Context) {
+	handleReports(c, moderation_log.ActionHide, report.StatusResolved, func(topicModel topic.Topic) {
+		if !topicModel.Trashed() {
+			topicModel.Delete()
+		}
+	})
+}
+
+// Resolve 举报成立，但已通过其他方式处理（如编辑了话题），不隐藏话题，自动隐藏的话题保持隐藏
+func (ctrl *ModerationController) Resolve(c *gin.Context) {
+	handleReports(c, moderation_log.ActionResolve, report.StatusResolved, nil)
+}
+
+// Dismiss 举报不成立，因举报被自动隐藏的话题恢复显示
+func (ctrl *ModerationController) Dismiss(c *gin.Context) {
+	handleReports(c, moderation_log.ActionDismiss, report.StatusDismissed, func(topicModel topic.Topic) {
+		if topicModel.Trashed() && moderation_log.LastByTopic(topicModel.GetStringID()).Action == moderation_log.ActionAutoHide {
+			topicModel.Restore()
+		}
+	})
+}
+
+// Logs 处理记录
+func (ctrl *ModerationController) Logs(c *gin.Context) {
+	request := requests.ModerationLogFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.ModerationLogFilter); !ok {
+		return
+	}
+
+	data, pager := moderation_log.Paginate(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// handleReports 处理举报所在话题的所有待处理举报，先执行 action 对话题的操作，再记录处理记录
+func handleReports(c *gin.Context, action, status string, apply func(topicModel topic.Topic)) {
+	reportModel := report.Get(c.Param("id"))
+	if reportModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	topicModel := topic.GetWithTrashed(reportModel.TopicID)
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if reportModel.Status != report.StatusPending {
+		response.ValidationError(c, map[string][]string{
+			"report": {"该举报已处理"},
+		})
+		return
+	}
+
+	request := requests.ModerationRequest{}
+	if ok := requests.Validate(c, &request, requests.Moderation); !ok {
+		return
+	}
+
+	if apply != nil {
+		apply(topicModel)
+	}
+	count, err := report.HandlePending(topicModel.GetStringID(), status)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "处理失败，请稍后尝试~")
+		return
+	}
+
+	moderationLog := moderation_log.ModerationLog{
+		ModeratorID: auth.CurrentUID(c),
+		TopicID:     topicModel.GetStringID(),
+		Action:      action,
+		ReportCount: count,
+		Note:        request.Note,
+	}
+	moderationLog.Create()
+	response.Data(c, moderationLog)
+}
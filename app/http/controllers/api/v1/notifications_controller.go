@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"gohub/app/models/notification"
+	"gohub/app/models/notification_preference"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type NotificationsController struct {
+	BaseAPIController
+}
+
+// Index 当前用户的通知，?unread=1 时只返回未读通知
+func (ctrl *NotificationsController) Index(c *gin.Context) {
+	request := requests.NotificationFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.NotificationFilter); !ok {
+		return
+	}
+
+	data, pager := notification.Paginate(c, auth.CurrentUID(c), cast.ToBool(request.Unread), 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// UnreadCount 当前用户的未读通知数
+func (ctrl *NotificationsController) UnreadCount(c *gin.Context) {
+	response.Data(c, gin.H{
+		"unread_count": notification.UnreadCount(auth.CurrentUID(c)),
+	})
+}
+
+// MarkAsRead 标记为已读，已读的通知同样返回成功
+func (ctrl *NotificationsController) MarkAsRead(c *gin.Context) {
+	notificationModel := notification.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if notificationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	notificationModel.MarkAsRead()
+	response.Data(c, notificationModel)
+}
+
+// MarkAllAsRead 将所有未读通知标记为已读
+func (ctrl *NotificationsController) MarkAllAsRead(c *gin.Context) {
+	response.Data(c, gin.H{
+		"marked": notification.MarkAllAsRead(auth.CurrentUID(c)),
+	})
+}
+
+// Preferences 当前用户各类通知的接收渠道
+func (ctrl *NotificationsController) Preferences(c *gin.Context) {
+	response.Data(c, notification_preference.AllForUser(auth.CurrentUID(c)))
+}
+
+// UpdatePreference 设置某类通知的接收渠道
+func (ctrl *NotificationsController) UpdatePreference(c *gin.Context) {
+	request := requests.NotificationPreferenceRequest{}
+	if ok := requests.Validate(c, &request, requests.NotificationPreferenceSave); !ok {
+		return
+	}
+
+	if err := notification_preference.Set(auth.CurrentUID(c), request.Type, request.Channels); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "设置失败，请稍后尝试~")
+		return
+	}
+	response.Data(c, notification_preference.AllForUser(auth.CurrentUID(c)))
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"gohub/app/models/personal_access_token"
+	"gohub/app/requests"
+	"gohub/pkg/app"
+	"gohub/pkg/auth"
+	"gohub/pkg/pat"
+	"gohub/pkg/response"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokensController 当前用户的个人访问令牌
+type PersonalAccessTokensController struct {
+	BaseAPIController
+}
+
+// Index 令牌列表，不包含令牌明文
+func (ctrl *PersonalAccessTokensController) Index(c *gin.Context) {
+	response.Data(c, personal_access_token.AllByUser(auth.CurrentUID(c)))
+}
+
+// Store 创建令牌，明文只在创建时返回一次
+func (ctrl *PersonalAccessTokensController) Store(c *gin.Context) {
+	request := requests.PersonalAccessTokenRequest{}
+	if ok := requests.Validate(c, &request, requests.PersonalAccessTokenSave); !ok {
+		return
+	}
+
+	plain, prefix, hashed := pat.Generate()
+	tokenModel := personal_access_token.PersonalAccessToken{
+		UserID:    auth.CurrentUID(c),
+		Name:      request.Name,
+		Prefix:    prefix,
+		Token:     hashed,
+		Scopes:    strings.Join(request.Scopes, ","),
+		RateLimit: request.RateLimit,
+	}
+	if request.ExpiresIn > 0 {
+		expiresAt := app.TimenowInTimezone().AddDate(0, 0, request.ExpiresIn)
+		tokenModel.ExpiresAt = &expiresAt
+	}
+	tokenModel.Create()
+
+	if tokenModel.ID > 0 {
+		response.CreatedJSON(c, gin.H{
+			"data":  tokenModel,
+			"token": plain,
+		})
+	} else {
+		response.Abort500(c, "创建失败，请稍后尝试~")
+	}
+}
+
+// Delete 删除令牌，令牌立即失效
+func (ctrl *PersonalAccessTokensController) Delete(c *gin.Context) {
+	tokenModel := personal_access_token.GetByUser(auth.CurrentUID(c), c.Param("id"))
+	if tokenModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if rowsAffected := tokenModel.Delete(); rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+	response.Abort500(c, "删除失败，请稍后尝试~")
+}
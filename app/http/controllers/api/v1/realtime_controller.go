@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"gohub/app/models/topic"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/realtime"
+	"gohub/pkg/response"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RealtimeController struct {
+	BaseAPIController
+}
+
+// WebSocket 实时推送，如 /realtime/ws?channels=topics,topic.1&token=xxx
+// 连接后可发送 {"action":"subscribe","channel":"topic.2"} 订阅其他频道
+func (ctrl *RealtimeController) WebSocket(c *gin.Context) {
+	channels, ok := subscribeChannels(c)
+	if !ok {
+		return
+	}
+	realtime.NewHub().ServeWebSocket(c, auth.CurrentUID(c), channels, channelAuthorizer(auth.CurrentUID(c)))
+}
+
+// SSE 不支持 WebSocket 的客户端使用，如 /realtime/sse?channels=topic.1&token=xxx
+func (ctrl *RealtimeController) SSE(c *gin.Context) {
+	channels, ok := subscribeChannels(c)
+	if !ok {
+		return
+	}
+	realtime.NewHub().ServeSSE(c, auth.CurrentUID(c), channels)
+}
+
+// subscribeChannels 连接时订阅的频道，总是包含自己的私有频道，用以接收通知
+func subscribeChannels(c *gin.Context) ([]string, bool) {
+	own := realtime.UserChannel(auth.CurrentUID(c))
+	channels := []string{own}
+	authorize := channelAuthorizer(auth.CurrentUID(c))
+	for _, channel := range realtime.ParseChannels(c.Query("channels")) {
+		if channel == own {
+			continue
+		}
+		if !authorize(channel) {
+			response.Abort403(c, "无权订阅频道 "+channel)
+			return nil, false
+		}
+		channels = append(channels, channel)
+	}
+	if len(channels) > config.GetInt("realtime.max_channels") {
+		response.BadRequest(c, nil, "订阅的频道过多")
+		return nil, false
+	}
+	return channels, true
+}
+
+// channelAuthorizer 频道的订阅权限：所有用户可以订阅 topics 和存在的话题，私有频道只有本人可以订阅
+func channelAuthorizer(userID string) realtime.Authorizer {
+	return func(channel string) bool {
+		switch {
+		case channel == realtime.ChannelTopics:
+			return true
+		case strings.HasPrefix(channel, realtime.TopicChannel("")):
+			topicID := strings.TrimPrefix(channel, realtime.TopicChannel(""))
+			return topic.GetPublished(topicID).ID > 0
+		default:
+			return channel == realtime.UserChannel(userID)
+		}
+	}
+}
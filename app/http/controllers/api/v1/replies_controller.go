@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"gohub/app/models/follow"
+	"gohub/app/models/reply"
+	"gohub/app/models/topic"
+	"gohub/app/notifications"
+	"gohub/app/policies"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/feed"
+	"gohub/pkg/filter"
+	"gohub/pkg/logger"
+	"gohub/pkg/realtime"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type RepliesController struct {
+	BaseAPIController
+}
+
+// Index 话题的回复，按楼层分页，每层楼包含其下的所有子回复
+func (ctrl *RepliesController) Index(c *gin.Context) {
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := reply.Paginate(c, topicModel.GetStringID(), config.GetInt("reply.perpage"))
+	response.JSON(c, gin.H{
+		"data":  reply.LoadChildren(data),
+		"pager": pager,
+	})
+}
+
+func (ctrl *RepliesController) Show(c *gin.Context) {
+	replyModel := reply.GetInTopic(c.Param("id"), c.Param("reply_id"))
+	if replyModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	response.Data(c, replyModel)
+}
+
+func (ctrl *RepliesController) Store(c *gin.Context) {
+
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if ok := policies.CanReplyTopic(c, topicModel); !ok {
+		response.Abort403(c, "话题已锁定，不能回复")
+		return
+	}
+
+	request := requests.ReplyRequest{}
+	if ok := requests.Validate(c, &request, requests.ReplySave); !ok {
+		return
+	}
+
+	replyModel := reply.Reply{
+		TopicID: topicModel.GetStringID(),
+		UserID:  auth.CurrentUID(c),
+		Body:    request.Body,
+		Depth:   1,
+	}
+
+	// 被回复的回复，用以通知其作者
+	var repliedTo reply.Reply
+	if cast.ToUint64(request.ParentID) > 0 {
+		parent := reply.GetInTopic(topicModel.GetStringID(), request.ParentID)
+		if parent.ID == 0 {
+			response.ValidationError(c, map[string][]string{
+				"parent_id": {"父回复不存在"},
+			})
+			return
+		}
+		repliedTo = parent
+		// 已达最大层级时向上查找，挂在父回复的同一层级
+		maxDepth := config.GetInt("reply.max_depth")
+		for parent.Depth >= maxDepth && !parent.IsRoot() {
+			parent = reply.Get(parent.ParentID)
+		}
+		// 不允许嵌套时（max_depth 为 1）作为第 1 层回复
+		if parent.Depth < maxDepth {
+			replyModel.ParentID = parent.GetStringID()
+			replyModel.Depth = parent.Depth + 1
+			replyModel.RootID = parent.RootID
+			if parent.IsRoot() {
+				replyModel.RootID = parent.GetStringID()
+			}
+		}
+	}
+
+	replyModel.Create()
+	if replyModel.ID > 0 {
+		follow.PublishActivity(feed.NewActivity(
+			feed.VerbReplyCreated, replyModel.UserID, replyModel.GetStringID(), replyModel.TopicID,
+		))
+		notifyReplied(c, topicModel, repliedTo, replyModel)
+		filter.RememberPost(replyModel.UserID, request.Body)
+		replyModel = reply.Get(replyModel.GetStringID())
+		logger.LogIf(realtime.NewHub().Publish(realtime.TopicChannel(replyModel.TopicID), "reply.created", replyModel))
+		response.Created(c, replyModel)
+	} else {
+		response.Abort500(c, "创建失败, 请稍后尝试~")
+	}
+}
+
+func (ctrl *RepliesController) Update(c *gin.Context) {
+
+	replyModel := reply.GetInTopic(c.Param("id"), c.Param("reply_id"))
+	if replyModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if ok := policies.CanModifyReply(c, replyModel); !ok {
+		response.Abort403(c)
+		return
+	}
+
+	request := requests.ReplyRequest{}
+	if ok := requests.Validate(c, &request, requests.ReplySave); !ok {
+		return
+	}
+
+	replyModel.Body = request.Body
+	rowsAffected := replyModel.Save()
+	if rowsAffected > 0 {
+		response.Data(c, replyModel)
+	} else {
+		response.Abort500(c, "更新失败, 请稍后尝试~")
+	}
+}
+
+func (ctrl *RepliesController) Delete(c *gin.Context) {
+
+	replyModel := reply.GetInTopic(c.Param("id"), c.Param("reply_id"))
+	if replyModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if ok := policies.CanDeleteReply(c, replyModel); !ok {
+		response.Abort403(c)
+		return
+	}
+
+	rowsAffected := replyModel.Delete()
+	if rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
+
+// notifyReplied 通知话题作者和被回复的人，不通知自己，被回复的人是话题作者时只通知一次
+func notifyReplied(c *gin.Context, topicModel topic.Topic, repliedTo, replyModel reply.Reply) {
+	replier := auth.CurrentUser(c)
+	if topicModel.UserID != replier.GetStringID() {
+		notifications.Send(topicModel.UserID, notifications.TopicReplied{
+			Topic:   topicModel,
+			Reply:   replyModel,
+			Replier: replier,
+		})
+	}
+	if repliedTo.ID > 0 && repliedTo.UserID != replier.GetStringID() && repliedTo.UserID != topicModel.UserID {
+		notifications.Send(repliedTo.UserID, notifications.ReplyReplied{
+			Topic:   topicModel,
+			Parent:  repliedTo,
+			Reply:   replyModel,
+			Replier: replier,
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"gohub/app/models/moderation_log"
+	"gohub/app/models/report"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/filter"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportsController struct {
+	BaseAPIController
+}
+
+// Store 举报话题，同一用户对同一话题只记录一次举报，重复举报返回已有的举报
+// 话题在版主处理之后被修改过时重新打开举报，重新打开的举报不计入自动隐藏的举报人数
+// 独立举报人数达到 moderation.auto_hide_threshold 时自动隐藏话题，等待版主处理
+func (ctrl *ReportsController) Store(c *gin.Context) {
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if topicModel.UserID == auth.CurrentUID(c) {
+		response.Abort403(c, "不能举报自己的话题")
+		return
+	}
+
+	request := requests.ReportRequest{}
+	if ok := requests.Validate(c, &request, requests.ReportSave); !ok {
+		return
+	}
+
+	reportModel := report.Report{
+		ReporterID:  auth.CurrentUID(c),
+		TopicID:     topicModel.GetStringID(),
+		Reason:      request.Reason,
+		Description: request.Description,
+	}
+	created, err := report.Add(&reportModel)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "举报失败，请稍后尝试~")
+		return
+	}
+	if !created {
+		response.Data(c, reportModel)
+		return
+	}
+
+	autoHide(topicModel)
+	response.Created(c, reportModel)
+}
+
+// autoHide 独立举报人数达到阈值时隐藏话题（移入回收站），并记录处理记录
+func autoHide(topicModel topic.Topic) {
+	threshold := config.GetInt64("moderation.auto_hide_threshold")
+	if threshold <= 0 {
+		return
+	}
+	count := report.PendingReporterCount(topicModel.GetStringID())
+	if count < threshold {
+		return
+	}
+	if rowsAffected := topicModel.Delete(); rowsAffected == 0 {
+		return
+	}
+	moderationLog := moderation_log.ModerationLog{
+		ModeratorID: "0",
+		TopicID:     topicModel.GetStringID(),
+		Action:      moderation_log.ActionAutoHide,
+		ReportCount: count,
+	}
+	moderationLog.Create()
+}
+
+// queueForReview 话题包含 review 模式的敏感词时，以系统身份提交举报，进入版主的审核队列
+// 之前的系统举报已处理过、且话题之后被修改过时，report.Add 会重新标记为待处理
+func queueForReview(topicModel topic.Topic) {
+	words := filter.NewFilter().Check(topicModel.Title + "\n" + topicModel.Body).Words(filter.ModeReview)
+	if len(words) == 0 {
+		return
+	}
+
+	description := "内容过滤：包含 " + strings.Join(words, "、")
+	reportModel := report.Report{
+		ReporterID:  report.ReporterSystem,
+		TopicID:     topicModel.GetStringID(),
+		Reason:      report.ReasonOther,
+		Description: description,
+	}
+	_, err := report.Add(&reportModel)
+	logger.LogIf(err)
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
+	"gohub/pkg/response"
+	"gohub/pkg/search"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchController struct {
+	BaseAPIController
+}
+
+// Index 全文搜索话题和用户，按相关度排序，匹配的关键词以高亮片段返回
+func (ctrl *SearchController) Index(c *gin.Context) {
+	request := requests.SearchRequest{}
+	if ok := requests.Validate(c, &request, requests.Search); !ok {
+		return
+	}
+
+	query := search.Query{
+		Text:       request.Q,
+		Type:       request.Type,
+		CategoryID: request.CategoryID,
+		UserID:     request.UserID,
+		From:       parseDate(request.From),
+		To:         parseDate(request.To),
+	}
+	// 结束日期当天的内容也包含在内
+	if !query.To.IsZero() {
+		query.To = query.To.AddDate(0, 0, 1)
+	}
+
+	s := search.NewSearch()
+	total, err := s.Count(query)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "搜索服务暂不可用, 请稍后尝试~")
+		return
+	}
+
+	pager, offset, limit := paginator.PaginateTotal(c, total, app.V1URL("search"), 10)
+	query.Offset, query.Limit = offset, limit
+	hits, err := s.Search(query)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "搜索服务暂不可用, 请稍后尝试~")
+		return
+	}
+
+	response.JSON(c, gin.H{
+		"data":  searchResults(hits, request.Q),
+		"pager": pager,
+	})
+}
+
+// searchResults 读取命中的话题和用户，按搜索结果的顺序组装，并生成高亮片段
+// 索引与数据库不一致时（如数据已删除但索引未更新）跳过该条结果
+func searchResults(hits []search.Hit, q string) []gin.H {
+	var topicIDs, userIDs []string
+	for _, hit := range hits {
+		switch hit.Type {
+		case search.TypeTopic:
+			topicIDs = append(topicIDs, hit.ID)
+		case search.TypeUser:
+			userIDs = append(userIDs, hit.ID)
+		}
+	}
+	topics := make(map[string]topic.Topic)
+	for _, t := range topic.GetByIDs(topicIDs) {
+		topics[t.GetStringID()] = t
+	}
+	users := make(map[string]user.User)
+	for _, u := range user.GetByIDs(userIDs) {
+		users[u.GetStringID()] = u
+	}
+
+	length := config.GetInt("search.highlight.length")
+	results := make([]gin.H, 0, len(hits))
+	for _, hit := range hits {
+		switch hit.Type {
+		case search.TypeTopic:
+			if t, ok := topics[hit.ID]; ok {
+				results = append(results, gin.H{
+					"type":  hit.Type,
+					"id":    hit.ID,
+					"score": hit.Score,
+					"highlight": gin.H{
+						"title": search.Highlight(t.Title, q, 0),
+						"body":  search.Highlight(t.Body, q, length),
+					},
+					"topic": t,
+				})
+			}
+		case search.TypeUser:
+			if u, ok := users[hit.ID]; ok {
+				results = append(results, gin.H{
+					"type":  hit.Type,
+					"id":    hit.ID,
+					"score": hit.Score,
+					"highlight": gin.H{
+						"name":         search.Highlight(u.Name, q, 0),
+						"introduction": search.Highlight(u.Introduction, q, length),
+					},
+					"user": u,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// parseDate 解析日期，支持 2006-01-02 和 2006/01/02，为空时返回零值
+func parseDate(date string) time.Time {
+	if len(date) == 0 {
+		return time.Time{}
+	}
+	t, _ := time.ParseInLocation("2006-01-02", strings.ReplaceAll(date, "/", "-"), app.TimenowInTimezone().Location())
+	return t
+}
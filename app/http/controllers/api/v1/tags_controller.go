@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"gohub/app/models/tag"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type TagsController struct {
+	BaseAPIController
+}
+
+// Index 热门标签，按话题数倒序，如 ?limit=20
+func (ctrl *TagsController) Index(c *gin.Context) {
+	request := requests.TagPopularRequest{}
+	if ok := requests.Validate(c, &request, requests.TagPopular); !ok {
+		return
+	}
+
+	limit := 20
+	if len(request.Limit) > 0 {
+		limit = cast.ToInt(request.Limit)
+	}
+	response.Data(c, tag.Popular(limit))
+}
+
+// Autocomplete 输入标签时的自动补全，如 ?q=go，支持别名，如 ?q=golang 补全为 Go
+func (ctrl *TagsController) Autocomplete(c *gin.Context) {
+	request := requests.TagAutocompleteRequest{}
+	if ok := requests.Validate(c, &request, requests.TagAutocomplete); !ok {
+		return
+	}
+
+	limit := 10
+	if len(request.Limit) > 0 {
+		limit = cast.ToInt(request.Limit)
+	}
+	response.Data(c, tag.Autocomplete(request.Q, limit))
+}
+
+func (ctrl *TagsController) Show(c *gin.Context) {
+	tagModel := tag.GetBySlug(c.Param("slug"))
+	if tagModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	response.Data(c, tagModel)
+}
+
+// Topics 标签下的话题，参数与话题列表相同，带 cursor 参数时使用游标分页
+func (ctrl *TagsController) Topics(c *gin.Context) {
+	tagModel := tag.GetBySlug(c.Param("slug"))
+	if tagModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.TopicFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicFilter); !ok {
+		return
+	}
+
+	if _, ok := c.GetQuery("cursor"); ok {
+		data, pager, err := topic.CursorPaginateByTag(c, tagModel.Slug, 10)
+		respondTopicsByCursor(c, data, pager, err)
+		return
+	}
+
+	data, pager := topic.PaginateByTag(c, tagModel.Slug, 10)
+	response.JSON(c, gin.H{
+		"tag":   tagModel,
+		"data":  topic.Summaries(data),
+		"pager": pager,
+	})
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"fmt"
+	"gohub/app/models/topic"
+	"gohub/app/models/topic_revision"
+	"gohub/app/policies"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/filter"
+	"gohub/pkg/response"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TopicRevisionsController struct {
+	BaseAPIController
+}
+
+// Index 话题的修订历史，每个版本附带与上一个版本的逐行对比，话题从未修改过时为空
+func (ctrl *TopicRevisionsController) Index(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 || !policies.CanViewTopic(c, topicModel) {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := topic_revision.Paginate(c, topicModel.GetStringID(), 10)
+	response.JSON(c, gin.H{
+		"data":  topic_revision.WithDiffs(topicModel.GetStringID(), data),
+		"pager": pager,
+	})
+}
+
+// Show 查看某个版本的完整内容，默认与上一个版本对比，?from= 可指定对比的版本
+func (ctrl *TopicRevisionsController) Show(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 || !policies.CanViewTopic(c, topicModel) {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.TopicRevisionCompareRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicRevisionCompare); !ok {
+		return
+	}
+
+	revisionModel := topic_revision.GetByVersion(topicModel.GetStringID(), c.Param("version"))
+	if revisionModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	var from topic_revision.TopicRevision
+	if len(request.From) > 0 {
+		from = topic_revision.GetByVersion(topicModel.GetStringID(), request.From)
+		if from.ID == 0 {
+			response.ValidationError(c, map[string][]string{
+				"from": {"版本不存在"},
+			})
+			return
+		}
+	} else if revisionModel.Version > 1 {
+		from = topic_revision.GetByVersion(topicModel.GetStringID(), revisionModel.Version-1)
+	}
+
+	response.Data(c, topic_revision.WithDiff{
+		TopicRevision: revisionModel,
+		Diff:          revisionModel.Compare(from),
+	})
+}
+
+// Rollback 将话题的标题和正文恢复为某个版本，作者和版主可以操作，回滚本身也会记录为一个新版本
+func (ctrl *TopicRevisionsController) Rollback(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if ok := policies.CanModifyTopic(c, topicModel); !ok {
+		response.Abort403(c)
+		return
+	}
+
+	revisionModel := topic_revision.GetByVersion(topicModel.GetStringID(), c.Param("version"))
+	if revisionModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if revisionModel.Title == topicModel.Title && revisionModel.Body == topicModel.Body {
+		response.ValidationError(c, map[string][]string{
+			"version": {"与当前内容相同，无需回滚"},
+		})
+		return
+	}
+	// 词表可能在该版本之后更新过，与发布时一样拒绝包含禁止发布的敏感词的内容
+	if words := filter.NewFilter().Check(revisionModel.Title + "\n" + revisionModel.Body).Words(filter.ModeReject); len(words) > 0 {
+		response.ValidationError(c, map[string][]string{
+			"version": {fmt.Sprintf("包含敏感词：%v", strings.Join(words, "、"))},
+		})
+		return
+	}
+
+	topicModel.Title = revisionModel.Title
+	topicModel.Body = revisionModel.Body
+	topicModel.EditorID = auth.CurrentUID(c)
+	topicModel.EditNote = fmt.Sprintf("回滚到第 %d 版", revisionModel.Version)
+	if rowsAffected := topicModel.Save(); rowsAffected == 0 {
+		response.Abort500(c, "回滚失败, 请稍后尝试~")
+		return
+	}
+	queueForReview(topicModel)
+	response.Data(c, topicModel)
+}
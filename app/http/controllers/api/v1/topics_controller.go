@@ -1,10 +1,16 @@
 package v1
 
 import (
+	"gohub/app/models/tag"
 	"gohub/app/models/topic"
 	"gohub/app/policies"
 	"gohub/app/requests"
+	"gohub/app/workers"
+	"gohub/pkg/app"
 	"gohub/pkg/auth"
+	"gohub/pkg/filter"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
 	"gohub/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -14,28 +20,43 @@ type TopicsController struct {
 	BaseAPIController
 }
 
+// Index 话题列表，支持过滤和多字段排序，如 ?category_id=3&created_after=2022-01-01&sort=-reply_count,created_at
+// 带 cursor 参数时使用游标分页，首页传参 cursor 为空，如 ?cursor=&sort=-created_at
+// 列表只返回摘要（excerpt），不返回正文
+// 热门话题按热度分值倒序，即 ?sort=-hot_score，热度由投票、收藏、回复数和发布时间计算，见 topic.HotScore
 func (ctrl *TopicsController) Index(c *gin.Context) {
-	request := requests.PaginationRequest{}
-	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+	request := requests.TopicFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicFilter); !ok {
+		return
+	}
+
+	if _, ok := c.GetQuery("cursor"); ok {
+		data, pager, err := topic.CursorPaginate(c, 10)
+		respondTopicsByCursor(c, data, pager, err)
 		return
 	}
 
 	data, pager := topic.Paginate(c, 10)
 	response.JSON(c, gin.H{
-		"data":  data,
+		"data":  topic.Summaries(data),
 		"pager": pager,
 	})
 }
 
 func (ctrl *TopicsController) Show(c *gin.Context) {
 	topicModel := topic.Get(c.Param("id"))
-	if topicModel.ID == 0 {
+	if c.GetBool("with_trashed") {
+		topicModel = topic.GetWithTrashed(c.Param("id"))
+	}
+	// 草稿和定时发布的话题只有作者和版主可见
+	if topicModel.ID == 0 || !policies.CanViewTopic(c, topicModel) {
 		response.Abort404(c)
 		return
 	}
 	response.Data(c, topicModel)
 }
 
+// Store 发布话题，status 为 draft 时保存为草稿，为 scheduled 时在 published_at 定时发布
 func (ctrl *TopicsController) Store(c *gin.Context) {
 
 	request := requests.TopicRequest{}
@@ -48,9 +69,26 @@ func (ctrl *TopicsController) Store(c *gin.Context) {
 		Body:       request.Body,
 		CategoryID: request.CategoryID,
 		UserID:     auth.CurrentUID(c),
+		Status:     topic.StatusPublished,
+	}
+	switch request.Status {
+	case topic.StatusDraft:
+		topicModel.Status = topic.StatusDraft
+	case topic.StatusScheduled:
+		publishedAt, _ := app.ParseDateTime(request.PublishedAt)
+		topicModel.Status = topic.StatusScheduled
+		topicModel.PublishedAt = &publishedAt
 	}
 	topicModel.Create()
 	if topicModel.ID > 0 {
+		if ok := syncTopicTags(c, &topicModel, request.Tags); !ok {
+			return
+		}
+		if topicModel.IsPublished() {
+			workers.TopicPublished(topicModel)
+		}
+		filter.RememberPost(topicModel.UserID, request.Title+"\n"+request.Body)
+		queueForReview(topicModel)
 		response.Created(c, topicModel)
 	} else {
 		response.Abort500(c, "创建失败, 请稍后尝试~")
@@ -78,8 +116,16 @@ func (ctrl *TopicsController) Update(c *gin.Context) {
 	topicModel.Title = request.Title
 	topicModel.Body = request.Body
 	topicModel.CategoryID = request.CategoryID
+	topicModel.EditorID = auth.CurrentUID(c)
 	rowsAffected := topicModel.Save()
 	if rowsAffected > 0 {
+		// 未传 tags 参数时不修改标签
+		if request.Tags != nil {
+			if ok := syncTopicTags(c, &topicModel, request.Tags); !ok {
+				return
+			}
+		}
+		queueForReview(topicModel)
 		response.Data(c, topicModel)
 	} else {
 		response.Abort500(c, "更新失败, 请稍后尝试~")
@@ -94,7 +140,7 @@ func (ctrl *TopicsController) Delete(c *gin.Context) {
 		return
 	}
 
-	if ok := policies.CanModifyTopic(c, topicModel); !ok {
+	if ok := policies.CanDeleteTopic(c, topicModel); !ok {
 		response.Abort403(c)
 		return
 	}
@@ -107,3 +153,211 @@ func (ctrl *TopicsController) Delete(c *gin.Context) {
 
 	response.Abort500(c, "删除失败, 请稍后尝试~")
 }
+
+// Trashed 回收站中的话题
+func (ctrl *TopicsController) Trashed(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := topic.PaginateTrashed(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Restore 从回收站恢复话题
+func (ctrl *TopicsController) Restore(c *gin.Context) {
+
+	topicModel := topic.GetTrashed(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := topicModel.Restore()
+	if rowsAffected > 0 {
+		response.Data(c, topic.Get(topicModel.GetStringID()))
+		return
+	}
+
+	response.Abort500(c, "恢复失败, 请稍后尝试~")
+}
+
+// ForceDelete 彻底删除回收站中的话题
+func (ctrl *TopicsController) ForceDelete(c *gin.Context) {
+
+	topicModel := topic.GetTrashed(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := topicModel.ForceDelete()
+	if rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
+
+// Drafts 当前用户的草稿和定时发布的话题
+func (ctrl *TopicsController) Drafts(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := topic.PaginateDrafts(c, auth.CurrentUID(c), 10)
+	response.JSON(c, gin.H{
+		"data":  topic.Summaries(data),
+		"pager": pager,
+	})
+}
+
+// Publish 立即发布草稿或定时发布的话题
+func (ctrl *TopicsController) Publish(c *gin.Context) {
+	topicModel, ok := unpublishedTopic(c)
+	if !ok {
+		return
+	}
+
+	if !topicModel.Publish() {
+		response.Abort500(c, "发布失败, 请稍后尝试~")
+		return
+	}
+	workers.TopicPublished(topicModel)
+	response.Data(c, topic.Get(topicModel.GetStringID()))
+}
+
+// Schedule 定时发布草稿，或修改定时发布的时间
+func (ctrl *TopicsController) Schedule(c *gin.Context) {
+	topicModel, ok := unpublishedTopic(c)
+	if !ok {
+		return
+	}
+
+	request := requests.TopicScheduleRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicSchedule); !ok {
+		return
+	}
+
+	publishedAt, _ := app.ParseDateTime(request.PublishedAt)
+	topicModel.Status = topic.StatusScheduled
+	topicModel.PublishedAt = &publishedAt
+	if rowsAffected := topicModel.Save(); rowsAffected == 0 {
+		response.Abort500(c, "更新失败, 请稍后尝试~")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Unschedule 取消定时发布，话题恢复为草稿
+func (ctrl *TopicsController) Unschedule(c *gin.Context) {
+	topicModel, ok := unpublishedTopic(c)
+	if !ok {
+		return
+	}
+
+	topicModel.Status = topic.StatusDraft
+	topicModel.PublishedAt = nil
+	if rowsAffected := topicModel.Save(); rowsAffected == 0 {
+		response.Abort500(c, "更新失败, 请稍后尝试~")
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Pin 置顶话题，置顶的话题在分类的话题列表中排在最前
+func (ctrl *TopicsController) Pin(c *gin.Context) {
+	setTopicState(c, func(topicModel *topic.Topic) int64 {
+		return topicModel.SetPinned(true)
+	})
+}
+
+// Unpin 取消置顶
+func (ctrl *TopicsController) Unpin(c *gin.Context) {
+	setTopicState(c, func(topicModel *topic.Topic) int64 {
+		return topicModel.SetPinned(false)
+	})
+}
+
+// Lock 锁定话题，锁定后不能回复
+func (ctrl *TopicsController) Lock(c *gin.Context) {
+	setTopicState(c, func(topicModel *topic.Topic) int64 {
+		return topicModel.SetLocked(true)
+	})
+}
+
+// Unlock 解除锁定
+func (ctrl *TopicsController) Unlock(c *gin.Context) {
+	setTopicState(c, func(topicModel *topic.Topic) int64 {
+		return topicModel.SetLocked(false)
+	})
+}
+
+// unpublishedTopic 获取当前用户可编辑的、尚未发布的话题，不存在、无权限或已发布时响应错误
+func unpublishedTopic(c *gin.Context) (topic.Topic, bool) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return topicModel, false
+	}
+	if ok := policies.CanModifyTopic(c, topicModel); !ok {
+		response.Abort403(c)
+		return topicModel, false
+	}
+	if topicModel.IsPublished() {
+		response.ValidationError(c, map[string][]string{
+			"status": {"话题已发布"},
+		})
+		return topicModel, false
+	}
+	return topicModel, true
+}
+
+// setTopicState 修改已发布话题的置顶或锁定状态，权限由路由中的 Permission 中间件校验
+func setTopicState(c *gin.Context, update func(topicModel *topic.Topic) int64) {
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if rowsAffected := update(&topicModel); rowsAffected == 0 {
+		response.Abort500(c, "更新失败, 请稍后尝试~")
+		return
+	}
+	response.Data(c, topic.Get(topicModel.GetStringID()))
+}
+
+// syncTopicTags 设置话题的标签，不存在的标签自动创建，失败时响应 500
+func syncTopicTags(c *gin.Context, topicModel *topic.Topic, names []string) bool {
+	tags, err := tag.FindOrCreate(names)
+	if err == nil {
+		err = topicModel.SyncTags(tags)
+	}
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "标签保存失败, 请稍后尝试~")
+		return false
+	}
+	return true
+}
+
+// respondTopicsByCursor 响应游标分页的话题列表，只返回摘要，游标无效时返回表单验证错误
+func respondTopicsByCursor(c *gin.Context, data []topic.Topic, pager paginator.CursorPage, err error) {
+	if err != nil {
+		response.ValidationError(c, map[string][]string{
+			"cursor": {"游标无效，请从第一页重新加载"},
+		})
+		return
+	}
+	response.JSON(c, gin.H{
+		"data":  topic.Summaries(data),
+		"pager": pager,
+	})
+}
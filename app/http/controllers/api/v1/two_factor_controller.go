@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+	"gohub/pkg/twofactor"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorController 两步验证
+type TwoFactorController struct {
+	BaseAPIController
+}
+
+// Store 开始开启两步验证，返回密钥和用于生成二维码的 otpauth:// 链接
+func (ctrl *TwoFactorController) Store(c *gin.Context) {
+	currentUser := auth.CurrentUser(c)
+	if currentUser.HasTwoFactorEnabled() {
+		response.Abort403(c, "已开启两步验证，请先关闭后再重新绑定")
+		return
+	}
+
+	secret, uri, err := twofactor.NewTwoFactor().Enroll(&currentUser)
+	if err != nil {
+		response.Abort500(c, "生成密钥失败, 请稍后尝试~")
+		return
+	}
+	response.JSON(c, gin.H{
+		"secret":           secret,
+		"provisioning_uri": uri,
+	})
+}
+
+// Confirm 输入身份验证器中的验证码，确认开启两步验证
+func (ctrl *TwoFactorController) Confirm(c *gin.Context) {
+	request := requests.TwoFactorCodeRequest{}
+	if ok := requests.Validate(c, &request, requests.TwoFactorCode); !ok {
+		return
+	}
+
+	currentUser := auth.CurrentUser(c)
+	if currentUser.HasTwoFactorEnabled() {
+		response.Abort403(c, "已开启两步验证")
+		return
+	}
+
+	codes, ok := twofactor.NewTwoFactor().Confirm(&currentUser, request.Code)
+	if !ok {
+		response.ValidationError(c, map[string][]string{"code": {"验证码错误"}})
+		return
+	}
+	response.JSON(c, gin.H{
+		"recovery_codes": codes,
+	})
+}
+
+// Delete 关闭两步验证
+func (ctrl *TwoFactorController) Delete(c *gin.Context) {
+	request := requests.TwoFactorDisableRequest{}
+	if ok := requests.Validate(c, &request, requests.TwoFactorDisable); !ok {
+		return
+	}
+
+	currentUser := auth.CurrentUser(c)
+	if !currentUser.HasTwoFactorEnabled() {
+		response.Abort403(c, "未开启两步验证")
+		return
+	}
+	if !currentUser.ComparePassword(request.Password) {
+		response.Unauthorized(c, "密码不正确")
+		return
+	}
+
+	tf := twofactor.NewTwoFactor()
+	if !tf.Verify(&currentUser, request.Code) {
+		response.ValidationError(c, map[string][]string{"code": {"验证码或恢复码错误"}})
+		return
+	}
+	if ok := tf.Disable(&currentUser); !ok {
+		response.Abort500(c, "关闭失败, 请稍后尝试~")
+		return
+	}
+	response.Success(c)
+}
+
+// RecoveryCodes 重新生成恢复码，旧恢复码全部作废
+func (ctrl *TwoFactorController) RecoveryCodes(c *gin.Context) {
+	request := requests.TwoFactorCodeRequest{}
+	if ok := requests.Validate(c, &request, requests.TwoFactorCode); !ok {
+		return
+	}
+
+	currentUser := auth.CurrentUser(c)
+	if !currentUser.HasTwoFactorEnabled() {
+		response.Abort403(c, "未开启两步验证")
+		return
+	}
+
+	tf := twofactor.NewTwoFactor()
+	if !tf.VerifyCode(&currentUser, request.Code) {
+		response.ValidationError(c, map[string][]string{"code": {"验证码错误"}})
+		return
+	}
+	response.JSON(c, gin.H{
+		"recovery_codes": tf.RegenerateRecoveryCodes(&currentUser),
+	})
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"gohub/app/models/user_identity"
+	"gohub/pkg/auth"
+	"gohub/pkg/oauth"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIdentitiesController 当前用户绑定的第三方账号
+type UserIdentitiesController struct {
+	BaseAPIController
+}
+
+// Index 已绑定的第三方账号
+func (ctrl *UserIdentitiesController) Index(c *gin.Context) {
+	response.Data(c, user_identity.AllByUser(auth.CurrentUID(c)))
+}
+
+// Redirect 获取绑定第三方账号的授权页链接，授权后在回调中完成绑定
+func (ctrl *UserIdentitiesController) Redirect(c *gin.Context) {
+	provider, ok := oauth.Get(c.Param("provider"))
+	if !ok {
+		response.Abort404(c, "不支持该第三方登录方式")
+		return
+	}
+	if user_identity.GetByUser(auth.CurrentUID(c), provider.Name()).ID > 0 {
+		response.Abort403(c, "已绑定该平台的账号，请先解绑")
+		return
+	}
+
+	url := oauth.NewOAuth().Begin(provider, oauth.State{
+		Action: oauth.ActionLink,
+		UserID: auth.CurrentUID(c),
+	})
+	if len(url) == 0 {
+		response.Abort500(c, "第三方平台暂时无法访问, 请稍后尝试~")
+		return
+	}
+	response.JSON(c, gin.H{
+		"url": url,
+	})
+}
+
+// Delete 解绑第三方账号
+func (ctrl *UserIdentitiesController) Delete(c *gin.Context) {
+	currentUser := auth.CurrentUser(c)
+	userIdentity := user_identity.GetByUser(currentUser.GetStringID(), c.Param("provider"))
+	if userIdentity.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	// 通过第三方登录注册的用户不知道自己的密码，至少保留一种可以找回账号的登录方式
+	if len(currentUser.Email) == 0 && len(currentUser.Phone) == 0 &&
+		len(user_identity.AllByUser(currentUser.GetStringID())) <= 1 {
+		response.Abort403(c, "请先绑定手机或邮箱，再解绑最后一个第三方账号")
+		return
+	}
+
+	if rowsAffected := userIdentity.Delete(); rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+	response.Abort500(c, "解绑失败, 请稍后尝试~")
+}
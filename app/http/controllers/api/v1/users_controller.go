@@ -1,12 +1,15 @@
 package v1
 
 import (
+	"gohub/app/models/topic"
 	"gohub/app/models/user"
 	"gohub/app/requests"
+	"gohub/pkg/app"
 	"gohub/pkg/auth"
 	"gohub/pkg/config"
 	"gohub/pkg/file"
 	"gohub/pkg/response"
+	"gohub/pkg/session"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,6 +39,32 @@ func (uc *UsersController) Index(c *gin.Context) {
 	})
 }
 
+// Topics 用户发布的话题，过滤和排序参数与话题列表相同
+func (uc *UsersController) Topics(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	request := requests.TopicFilterRequest{}
+	if ok := requests.Validate(c, &request, requests.TopicFilter); !ok {
+		return
+	}
+
+	if _, ok := c.GetQuery("cursor"); ok {
+		data, pager, err := topic.CursorPaginateByUser(c, userModel.GetStringID(), 10)
+		respondTopicsByCursor(c, data, pager, err)
+		return
+	}
+
+	data, pager := topic.PaginateByUser(c, userModel.GetStringID(), 10)
+	response.JSON(c, gin.H{
+		"data":  topic.Summaries(data),
+		"pager": pager,
+	})
+}
+
 func (uc *UsersController) UpdateProfile(c *gin.Context) {
 	request := requests.UserRequest{}
 	if ok := requests.Validate(c, &request, requests.UserSave); !ok {
@@ -60,8 +89,11 @@ func (uc *UsersController) UpdateEmail(c *gin.Context) {
 		return
 	}
 
+	// 新旧 Email 均已通过验证码确认
+	verifiedAt := app.TimenowInTimezone()
 	currentUser := auth.CurrentUser(c)
 	currentUser.Email = request.Email
+	currentUser.EmailVerifiedAt = &verifiedAt
 	rowsAffected := currentUser.Save()
 
 	if rowsAffected > 0 {
@@ -78,8 +110,11 @@ func (uc *UsersController) UpdatePhone(c *gin.Context) {
 		return
 	}
 
+	// 新旧手机号均已通过验证码确认
+	verifiedAt := app.TimenowInTimezone()
 	currentUser := auth.CurrentUser(c)
 	currentUser.Phone = request.Phone
+	currentUser.PhoneVerifiedAt = &verifiedAt
 	rowsAffected := currentUser.Save()
 
 	if rowsAffected > 0 {
@@ -127,3 +162,82 @@ func (uc *UsersController) UpdateAvatar(c *gin.Context) {
 
 	response.Data(c, currentUser)
 }
+
+// Delete 删除用户，移入回收站，同时注销该用户的所有会话
+func (uc *UsersController) Delete(c *gin.Context) {
+
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if userModel.GetStringID() == auth.CurrentUID(c) {
+		response.Abort403(c, "不能删除自己")
+		return
+	}
+
+	rowsAffected := userModel.Delete()
+	if rowsAffected > 0 {
+		session.NewRegistry().RevokeAll(userModel.GetStringID())
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
+
+// Trashed 回收站中的用户
+func (uc *UsersController) Trashed(c *gin.Context) {
+	request := requests.PaginationRequest{}
+	if ok := requests.Validate(c, &request, requests.Pagination); !ok {
+		return
+	}
+
+	data, pager := user.PaginateTrashed(c, 10)
+	response.JSON(c, gin.H{
+		"data":  data,
+		"pager": pager,
+	})
+}
+
+// Restore 从回收站恢复用户
+func (uc *UsersController) Restore(c *gin.Context) {
+
+	userModel := user.GetTrashed(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	rowsAffected := userModel.Restore()
+	if rowsAffected > 0 {
+		response.Data(c, user.Get(userModel.GetStringID()))
+		return
+	}
+
+	response.Abort500(c, "恢复失败, 请稍后尝试~")
+}
+
+// ForceDelete 彻底删除回收站中的用户，发布过话题的用户不允许彻底删除
+func (uc *UsersController) ForceDelete(c *gin.Context) {
+
+	userModel := user.GetTrashed(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if topic.HasByUser(userModel.GetStringID()) {
+		response.Abort403(c, "该用户发布过话题（包括回收站中的话题），请先彻底删除这些话题")
+		return
+	}
+
+	rowsAffected := userModel.ForceDelete()
+	if rowsAffected > 0 {
+		response.Success(c)
+		return
+	}
+
+	response.Abort500(c, "删除失败, 请稍后尝试~")
+}
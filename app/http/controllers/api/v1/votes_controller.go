@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/vote"
+	"gohub/app/notifications"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VotesController struct {
+	BaseAPIController
+}
+
+// Store 给话题投票，重复投相同的票结果不变，投相反的票为改票
+func (ctrl *VotesController) Store(c *gin.Context) {
+	topicModel := topic.GetPublished(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if topicModel.UserID == auth.CurrentUID(c) {
+		response.Abort403(c, "不能给自己的话题投票")
+		return
+	}
+
+	request := requests.VoteRequest{}
+	if ok := requests.Validate(c, &request, requests.VoteSave); !ok {
+		return
+	}
+
+	value := vote.Up
+	if request.Value == "down" {
+		value = vote.Down
+	}
+	previous := vote.Get(auth.CurrentUID(c), topicModel.GetStringID())
+	if err := vote.Cast(auth.CurrentUID(c), topicModel.GetStringID(), value); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "投票失败，请稍后尝试~")
+		return
+	}
+	// 新的赞成票才通知作者，重复投票不重复通知
+	if value == vote.Up && previous.Value != vote.Up {
+		notifications.Send(topicModel.UserID, notifications.TopicVoted{
+			Topic: topicModel,
+			Voter: auth.CurrentUser(c),
+		})
+	}
+	respondVote(c, topicModel.GetStringID())
+}
+
+// Delete 撤销投票，未投过票时同样返回成功
+func (ctrl *VotesController) Delete(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	if err := vote.Retract(auth.CurrentUID(c), topicModel.GetStringID()); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "撤销投票失败，请稍后尝试~")
+		return
+	}
+	respondVote(c, topicModel.GetStringID())
+}
+
+// respondVote 返回当前用户的投票（未投票时为 0）和话题最新的投票数
+func respondVote(c *gin.Context, topicID string) {
+	topicModel := topic.Get(topicID)
+	response.Data(c, gin.H{
+		"vote":           vote.Get(auth.CurrentUID(c), topicID).Value,
+		"upvote_count":   topicModel.UpvoteCount,
+		"downvote_count": topicModel.DownvoteCount,
+	})
+}
@@ -5,18 +5,31 @@ import (
 	"gohub/app/models/user"
 	"gohub/pkg/config"
 	"gohub/pkg/jwt"
+	"gohub/pkg/pat"
 	"gohub/pkg/response"
+	"gohub/pkg/session"
 
 	"github.com/gin-gonic/gin"
 )
 
 func AuthJWT() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// 机器客户端使用个人访问令牌，根据前缀区分
+		if token := pat.FromHeader(c); len(token) > 0 {
+			authPersonalAccessToken(c, token)
+			return
+		}
+
 		// 从标头 Authorization:Bearer xxxxx 中获取信息，并验证 JWT 的准确性
 		claims, err := jwt.NewJWT().ParserToken(c)
 
 		// JWT 解析失败，有错误发生
 		if err != nil {
+			// 令牌已被吊销（登出、刷新令牌被重复使用等），提示用户重新登录
+			if err == jwt.ErrTokenRevoked {
+				response.Unauthorized(c, "令牌已失效，请重新登录")
+				return
+			}
 			response.Unauthorized(c, fmt.Sprintf("请查看 %v 相关的接口认证文档", config.GetString("app.name")))
 			return
 		}
@@ -28,10 +41,17 @@ func AuthJWT() gin.HandlerFunc {
 			return
 		}
 
+		// 会话已被注销（登出、在其他设备上被踢下线）
+		if ok := session.NewRegistry().Touch(c, userModel.GetStringID(), claims.FamilyID); !ok {
+			response.Unauthorized(c, "登录会话已失效，请重新登录")
+			return
+		}
+
 		// 将用户信息存入 gin.context 里，后续 auth 包将从这里拿到当前用户数据
 		c.Set("current_user_id", userModel.GetStringID())
 		c.Set("current_user_name", userModel.Name)
 		c.Set("current_user", userModel)
+		c.Set("current_session_id", claims.FamilyID)
 
 		c.Next()
 	}
@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"gohub/app/models/personal_access_token"
+	"gohub/app/models/user"
+	"gohub/pkg/config"
+	"gohub/pkg/pat"
+	"gohub/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authPersonalAccessToken 使用个人访问令牌认证，由 AuthJWT 根据令牌前缀调用
+// 认证成功后设置与 JWT 相同的 current_user 等信息，另外设置 current_token_id 和 current_token_scopes
+func authPersonalAccessToken(c *gin.Context, plain string) {
+	token := personal_access_token.GetByToken(pat.Hash(plain))
+	if token.ID == 0 {
+		response.Unauthorized(c, "个人访问令牌无效或已被删除")
+		return
+	}
+	if token.IsExpired() {
+		response.Unauthorized(c, "个人访问令牌已过期")
+		return
+	}
+
+	// 只读请求需要 read 作用域，其余请求需要 write 作用域
+	if isReadOnlyMethod(c.Request.Method) {
+		if !token.Can(pat.ScopeRead) {
+			response.Abort403(c, "个人访问令牌缺少 read 作用域")
+			return
+		}
+	} else if !token.Can(pat.ScopeWrite) {
+		response.Abort403(c, "个人访问令牌缺少 write 作用域")
+		return
+	}
+
+	userModel := user.Get(token.UserID)
+	if userModel.ID == 0 {
+		response.Unauthorized(c, "找不到对应用户，用户可能已删除")
+		return
+	}
+
+	// 每个令牌单独限流
+	limit := token.RateLimit
+	if len(limit) == 0 {
+		limit = config.GetString("pat.rate_limit")
+	}
+	if ok := limitHandler(c, "pat:"+token.GetStringID(), limit); !ok {
+		return
+	}
+
+	// 记录最后使用时间，间隔较短时不重复写入
+	now := time.Now()
+	interval := time.Duration(config.GetInt64("pat.touch_interval", 60)) * time.Second
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= interval || token.LastUsedIP != c.ClientIP() {
+		token.Touch(now, c.ClientIP())
+	}
+
+	c.Set("current_user_id", userModel.GetStringID())
+	c.Set("current_user_name", userModel.Name)
+	c.Set("current_user", userModel)
+	c.Set("current_token_id", token.GetStringID())
+	c.Set("current_token_scopes", token.ScopeList())
+
+	c.Next()
+}
+
+// isReadOnlyMethod 不会修改数据的请求方法
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -48,6 +48,21 @@ func LimitPerRoute(limit string) gin.HandlerFunc {
 	}
 }
 
+// LimitPerUser 限流中间件，针对当前用户进行限流，需在 AuthJWT 之后使用
+// name 为限流的名称，多个路由使用相同的名称时共享次数，如发私信的多个接口
+func LimitPerUser(name, limit string) gin.HandlerFunc {
+	if app.IsTesting() {
+		limit = "1000000-H"
+	}
+	return func(c *gin.Context) {
+		key := limiter.GetKeyUser(c, name)
+		if ok := limitHandler(c, key, limit); !ok {
+			return
+		}
+		c.Next()
+	}
+}
+
 // limitHandler 处理限流
 func limitHandler(c *gin.Context, key, limit string) bool {
 	// 获取超额的情况
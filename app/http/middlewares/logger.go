@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"gohub/pkg/helpers"
 	"gohub/pkg/logger"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,9 +26,11 @@ func (r responseBodyWriter) Write(b []byte) (int, error) {
 // Logger 记录请求日志
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 获取 response 内容
+		// 获取 response 内容，WebSocket 和 SSE 为长连接，不记录响应内容
 		w := &responseBodyWriter{body: &bytes.Buffer{}, ResponseWriter: c.Writer}
-		c.Writer = w
+		if !isStreamingRequest(c) {
+			c.Writer = w
+		}
 
 		// 设置开始时间
 		start := time.Now()
@@ -66,3 +69,9 @@ func Logger() gin.HandlerFunc {
 		}
 	}
 }
+
+// isStreamingRequest 是否为 WebSocket 或 SSE 请求
+func isStreamingRequest(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader("Upgrade"), "websocket") ||
+		strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
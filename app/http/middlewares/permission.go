@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Permission 权限中间件，需在 AuthJWT 之后使用，当前用户必须拥有所有传参的权限
+//         ccGroup.DELETE("/:id", middlewares.AuthJWT(), middlewares.Permission("category.delete"), cc.Delete)
+func Permission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, permission := range permissions {
+			if !auth.Can(c, permission) {
+				response.Abort403(c)
+				return
+			}
+		}
+		c.Next()
+	}
+}
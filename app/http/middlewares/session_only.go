@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionOnly 需在 AuthJWT 之后使用，拒绝个人访问令牌
+// 用于管理令牌、登录会话、密码和两步验证等账号安全相关的接口，令牌泄露时不至于被接管账号
+func SessionOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if auth.IsPersonalAccessToken(c) {
+			response.Abort403(c, "该接口不支持使用个人访问令牌，请登录后操作")
+			return
+		}
+		c.Next()
+	}
+}
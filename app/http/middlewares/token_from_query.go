@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// TokenFromQuery 浏览器的 WebSocket 和 EventSource 无法设置请求标头，允许通过 ?token= 传递访问令牌，
+// 放在 AuthJWT 之前，仅用于实时推送的路由，其他接口的令牌不应出现在 URL 中
+// 令牌复制到请求标头后从 URL 中移除，避免被 Logger 记录到日志中
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		if token := query.Get("token"); len(token) > 0 {
+			if len(c.GetHeader("Authorization")) == 0 {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+			query.Del("token")
+			c.Request.URL.RawQuery = query.Encode()
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Verified 需在 AuthJWT 之后使用，当前用户至少需要验证过手机或 Email 中的一个
+func Verified() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := auth.CurrentUser(c)
+		if !currentUser.HasVerifiedEmail() && !currentUser.HasVerifiedPhone() {
+			response.Abort403(c, "请先验证手机或 Email")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithTrashed 请求参数带有 with_trashed=1 时，查询结果包含回收站中的数据，需拥有 permission 权限
+// 需要在 AuthJWT 之后使用，否则获取不到当前用户，带 with_trashed 参数时总是返回 403
+//         tcGroup.GET("", middlewares.AuthJWT(), middlewares.WithTrashed("topic.restore"), tc.Index)
+func WithTrashed(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Query("with_trashed") {
+		case "", "0", "false":
+			c.Next()
+			return
+		}
+
+		if !auth.Can(c, permission) {
+			response.Abort403(c, "没有权限查看已删除的数据")
+			return
+		}
+		// paginator.Paginate 读取该值
+		c.Set("with_trashed", true)
+		c.Next()
+	}
+}
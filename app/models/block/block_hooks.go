@@ -0,0 +1,11 @@
+package block
+
+// func (block *Block) BeforeSave(tx *gorm.DB) (err error) {}
+// func (block *Block) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (block *Block) AfterCreate(tx *gorm.DB) (err error) {}
+// func (block *Block) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (block *Block) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (block *Block) AfterSave(tx *gorm.DB) (err error) {}
+// func (block *Block) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (block *Block) AfterDelete(tx *gorm.DB) (err error) {}
+// func (block *Block) AfterFind(tx *gorm.DB) (err error) {}
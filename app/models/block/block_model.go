@@ -0,0 +1,19 @@
+package block
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+)
+
+// Block 黑名单，UserID 屏蔽了 BlockedID（user_id + blocked_id 唯一），双方都不能再给对方发私信
+type Block struct {
+	models.BaseModel
+
+	UserID    string `json:"-"`
+	BlockedID string `json:"blocked_id,omitempty"`
+
+	// 通过 blocked_id 关联被屏蔽的用户
+	Blocked user.User `gorm:"foreignKey:BlockedID" json:"blocked"`
+
+	models.CommonTimestampsField
+}
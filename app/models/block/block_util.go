@@ -0,0 +1,46 @@
+package block
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// IsBlocked userID 是否屏蔽了 blockedID
+func IsBlocked(userID, blockedID string) bool {
+	var count int64
+	database.DB.Model(Block{}).Where("user_id = ? AND blocked_id = ?", userID, blockedID).Count(&count)
+	return count > 0
+}
+
+// Add 屏蔽用户，已屏蔽时不做任何处理
+func Add(userID, blockedID string) error {
+	block := Block{UserID: userID, BlockedID: blockedID}
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "blocked_id"}},
+		DoNothing: true,
+	}).Create(&block).Error
+}
+
+// Remove 取消屏蔽，未屏蔽时不做任何处理
+func Remove(userID, blockedID string) error {
+	return database.DB.
+		Where("user_id = ? AND blocked_id = ?", userID, blockedID).
+		Delete(&Block{}).
+		Error
+}
+
+// PaginateByUser 用户屏蔽的用户分页
+func PaginateByUser(c *gin.Context, userID string, perPage int) (blocks []Block, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(Block{}).Where("user_id = ?", userID),
+		&blocks,
+		app.V1URL("user/blocks"),
+		perPage,
+	)
+	return
+}
@@ -1,6 +1,18 @@
 package category
 
-// func (category *Category) BeforeSave(tx *gorm.DB) (err error) {}
+import (
+	"gohub/pkg/filter"
+
+	"gorm.io/gorm"
+)
+
+// BeforeSave 替换 mask 模式的敏感词
+func (category *Category) BeforeSave(tx *gorm.DB) (err error) {
+	category.Name = filter.NewFilter().Mask(category.Name)
+	category.Description = filter.NewFilter().Mask(category.Description)
+	return nil
+}
+
 // func (category *Category) BeforeCreate(tx *gorm.DB) (err error) {}
 // func (category *Category) AfterCreate(tx *gorm.DB) (err error) {}
 // func (category *Category) BeforeUpdate(tx *gorm.DB) (err error) {}
@@ -13,6 +13,7 @@ type Category struct {
 	Description string `json:"description,omitempty"`
 
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 func (category *Category) Create() {
@@ -24,7 +25,20 @@ func (category *Category) Save() (rowsAffected int64) {
 	return result.RowsAffected
 }
 
+// Delete 软删除，数据移入回收站
 func (category *Category) Delete() (rowsAffected int64) {
 	result := database.DB.Delete(&category)
 	return result.RowsAffected
 }
+
+// Restore 从回收站恢复
+func (category *Category) Restore() (rowsAffected int64) {
+	result := database.DB.Unscoped().Model(&category).Update("deleted_at", nil)
+	return result.RowsAffected
+}
+
+// ForceDelete 彻底删除，无法恢复
+func (category *Category) ForceDelete() (rowsAffected int64) {
+	result := database.DB.Unscoped().Delete(&category)
+	return result.RowsAffected
+}
@@ -39,3 +39,27 @@ func Paginate(c *gin.Context, perPage int) (categories []Category, paging pagina
 	)
 	return
 }
+
+// GetWithTrashed 通过 ID 获取，包含回收站中的数据
+func GetWithTrashed(idstr string) (category Category) {
+	database.DB.Unscoped().Where("id = ?", idstr).First(&category)
+	return
+}
+
+// GetTrashed 通过 ID 获取回收站中的数据
+func GetTrashed(idstr string) (category Category) {
+	database.DB.Unscoped().Where("id = ?", idstr).Where("deleted_at IS NOT NULL").First(&category)
+	return
+}
+
+// PaginateTrashed 回收站分页内容
+func PaginateTrashed(c *gin.Context, perPage int) (categories []Category, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Unscoped().Model(Category{}).Where("deleted_at IS NOT NULL"),
+		&categories,
+		app.V1URL("trash/"+database.TableName(&Category{})),
+		perPage,
+	)
+	return
+}
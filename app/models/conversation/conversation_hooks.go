@@ -0,0 +1,76 @@
+package conversation
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// func (conversation *Conversation) BeforeSave(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) AfterCreate(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) AfterSave(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) AfterDelete(tx *gorm.DB) (err error) {}
+// func (conversation *Conversation) AfterFind(tx *gorm.DB) (err error) {}
+
+// RefreshStats 更新两个用户双方会话的最后一条消息和未读数，由 message 的 AfterCreate 钩子调用
+// 对方还没有会话时自动创建，收到第一条消息后才出现在对方的会话列表中
+func RefreshStats(tx *gorm.DB, userID, peerID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var last struct {
+		ID        string
+		Body      string
+		CreatedAt time.Time
+	}
+	err := db.Table("messages").
+		Select("id, body, created_at").
+		Where("(sender_id = ? AND recipient_id = ?) OR (sender_id = ? AND recipient_id = ?)", userID, peerID, peerID, userID).
+		Order("id desc").
+		Limit(1).
+		Scan(&last).
+		Error
+	if err != nil || len(last.ID) == 0 {
+		return err
+	}
+
+	for _, pair := range [][2]string{{userID, peerID}, {peerID, userID}} {
+		owner, peer := pair[0], pair[1]
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "peer_id"}},
+			DoNothing: true,
+		}).Create(&Conversation{UserID: owner, PeerID: peer}).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Table("conversations").Where("user_id = ? AND peer_id = ?", owner, peer).UpdateColumns(map[string]interface{}{
+			"last_message_id": last.ID,
+			"last_message":    excerpt(last.Body, 100),
+			"last_message_at": last.CreatedAt,
+			"unread_count": gorm.Expr(
+				"(SELECT COUNT(*) FROM messages WHERE sender_id = ? AND recipient_id = ? AND id > conversations.last_read_message_id)",
+				peer, owner,
+			),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excerpt 截取前 length 个字符作为摘要
+func excerpt(text string, length int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length]) + "..."
+}
@@ -0,0 +1,55 @@
+package conversation
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+	"time"
+)
+
+// Conversation 私信会话，两个用户之间的私信每人各有一条会话（user_id + peer_id 唯一），
+// 分别记录自己的已读位置和未读数，会话中的消息见 message.Message
+type Conversation struct {
+	models.BaseModel
+
+	UserID string `json:"-"`
+	PeerID string `json:"peer_id,omitempty"`
+	// 对方发来的未读消息数，由 RefreshStats 统计
+	UnreadCount int64 `json:"unread_count"`
+	// 已读到的消息 ID，之后对方发来的消息为未读
+	LastReadMessageID string `gorm:"default:0" json:"last_read_message_id,omitempty"`
+	// 最后一条消息的 ID、摘要和发送时间
+	LastMessageID string     `gorm:"default:0" json:"last_message_id,omitempty"`
+	LastMessage   string     `json:"last_message,omitempty"`
+	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
+
+	// 通过 peer_id 关联对方用户
+	Peer user.User `gorm:"foreignKey:PeerID" json:"peer"`
+
+	models.CommonTimestampsField
+}
+
+// MarkAsRead 将对方发来的消息全部标记为已读
+func (conversation *Conversation) MarkAsRead() (rowsAffected int64) {
+	if conversation.UnreadCount == 0 {
+		return 0
+	}
+	var ids []string
+	database.DB.Table("messages").
+		Where("sender_id = ? AND recipient_id = ?", conversation.PeerID, conversation.UserID).
+		Order("id desc").
+		Limit(1).
+		Pluck("id", &ids)
+	if len(ids) == 0 {
+		return 0
+	}
+	result := database.DB.Model(&conversation).UpdateColumns(map[string]interface{}{
+		"last_read_message_id": ids[0],
+		"unread_count":         0,
+	})
+	if result.RowsAffected > 0 {
+		conversation.LastReadMessageID = ids[0]
+		conversation.UnreadCount = 0
+	}
+	return result.RowsAffected
+}
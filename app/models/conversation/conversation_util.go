@@ -0,0 +1,59 @@
+package conversation
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// GetByUser 获取用户的某个会话
+func GetByUser(userID, idstr string) (conversation Conversation) {
+	database.DB.Preload("Peer").Where("user_id = ? AND id = ?", userID, idstr).First(&conversation)
+	return
+}
+
+// GetByPeer 获取用户与 peerID 的会话
+func GetByPeer(userID, peerID string) (conversation Conversation) {
+	database.DB.Preload("Peer").Where("user_id = ? AND peer_id = ?", userID, peerID).First(&conversation)
+	return
+}
+
+// Start 开始与 peerID 的会话，已有会话时返回已有的会话
+func Start(userID, peerID string) (Conversation, error) {
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "peer_id"}},
+		DoNothing: true,
+	}).Create(&Conversation{UserID: userID, PeerID: peerID}).Error
+	if err != nil {
+		return Conversation{}, err
+	}
+	return GetByPeer(userID, peerID), nil
+}
+
+// Paginate 用户的会话分页，unreadOnly 为 true 时只返回有未读消息的会话
+func Paginate(c *gin.Context, userID string, unreadOnly bool, perPage int) (conversations []Conversation, paging paginator.Page) {
+	query := database.DB.Model(Conversation{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("unread_count > 0")
+	}
+	paging = paginator.Paginate(
+		c,
+		query,
+		&conversations,
+		app.V1URL(database.TableName(&Conversation{})),
+		perPage,
+	)
+	return
+}
+
+// UnreadCount 用户所有会话的未读消息数
+func UnreadCount(userID string) (count int64) {
+	database.DB.Model(Conversation{}).
+		Select("COALESCE(SUM(unread_count), 0)").
+		Where("user_id = ?", userID).
+		Scan(&count)
+	return
+}
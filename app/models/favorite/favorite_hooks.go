@@ -0,0 +1,57 @@
+package favorite
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// func (favorite *Favorite) BeforeSave(tx *gorm.DB) (err error) {}
+// func (favorite *Favorite) BeforeCreate(tx *gorm.DB) (err error) {}
+
+// AfterCreate 更新话题的收藏数
+func (favorite *Favorite) AfterCreate(tx *gorm.DB) (err error) {
+	return topic.RefreshFavoriteStats(tx, favorite.TopicID)
+}
+
+// func (favorite *Favorite) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (favorite *Favorite) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (favorite *Favorite) AfterSave(tx *gorm.DB) (err error) {}
+// func (favorite *Favorite) BeforeDelete(tx *gorm.DB) (err error) {}
+
+// AfterDelete 更新话题的收藏数
+func (favorite *Favorite) AfterDelete(tx *gorm.DB) (err error) {
+	if len(favorite.TopicID) == 0 {
+		return nil
+	}
+	return topic.RefreshFavoriteStats(tx, favorite.TopicID)
+}
+
+// func (favorite *Favorite) AfterFind(tx *gorm.DB) (err error) {}
+
+// deleteByUser 彻底删除用户时删除其所有收藏，并重新统计相关话题的收藏数
+func deleteByUser(tx *gorm.DB, userID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var topicIDs []string
+	if err := db.Model(&Favorite{}).Where("user_id = ?", userID).Distinct().Pluck("topic_id", &topicIDs).Error; err != nil {
+		return err
+	}
+	if len(topicIDs) == 0 {
+		return nil
+	}
+	if err := db.Exec("DELETE FROM favorites WHERE user_id = ?", userID).Error; err != nil {
+		return err
+	}
+	for _, topicID := range topicIDs {
+		if err := topic.RefreshFavoriteStats(tx, topicID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
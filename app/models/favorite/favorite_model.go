@@ -0,0 +1,19 @@
+package favorite
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/topic"
+)
+
+// Favorite 用户收藏的话题（user_id + topic_id 唯一）
+type Favorite struct {
+	models.BaseModel
+
+	UserID  string `json:"user_id,omitempty"`
+	TopicID string `json:"topic_id,omitempty"`
+
+	// 通过 topic_id 关联话题
+	Topic topic.Topic `json:"topic"`
+
+	models.CommonTimestampsField
+}
@@ -0,0 +1,50 @@
+package favorite
+
+import (
+	"gohub/app/models/topic"
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// IsFavorited 用户是否收藏了话题
+func IsFavorited(userID, topicID string) bool {
+	var count int64
+	database.DB.Model(Favorite{}).Where("user_id = ? AND topic_id = ?", userID, topicID).Count(&count)
+	return count > 0
+}
+
+// Add 收藏话题，已收藏时不做任何处理
+func Add(userID, topicID string) error {
+	favorite := Favorite{UserID: userID, TopicID: topicID}
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "topic_id"}},
+		DoNothing: true,
+	}).Create(&favorite).Error
+}
+
+// Remove 取消收藏，未收藏时不做任何处理
+func Remove(userID, topicID string) error {
+	return database.DB.
+		Where("user_id = ? AND topic_id = ?", userID, topicID).
+		Delete(&Favorite{TopicID: topicID}).
+		Error
+}
+
+// PaginateByUser 用户收藏的话题分页，不包含已删除（在回收站中）的话题
+func PaginateByUser(c *gin.Context, userID string, perPage int) (favorites []Favorite, paging paginator.Page) {
+	query := database.DB.Model(Favorite{}).
+		Where("user_id = ?", userID).
+		Where("topic_id IN (?)", database.DB.Model(&topic.Topic{}).Select("id"))
+	paging = paginator.Paginate(
+		c,
+		query,
+		&favorites,
+		app.V1URL("user/favorites"),
+		perPage,
+	)
+	return
+}
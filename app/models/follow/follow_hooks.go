@@ -0,0 +1,30 @@
+package follow
+
+import (
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+// func (follow *Follow) BeforeSave(tx *gorm.DB) (err error) {}
+// func (follow *Follow) BeforeCreate(tx *gorm.DB) (err error) {}
+
+// AfterCreate 更新双方的粉丝数和关注数
+func (follow *Follow) AfterCreate(tx *gorm.DB) (err error) {
+	return user.RefreshFollowStats(tx, follow.FollowerID, follow.FollowingID)
+}
+
+// func (follow *Follow) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (follow *Follow) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (follow *Follow) AfterSave(tx *gorm.DB) (err error) {}
+// func (follow *Follow) BeforeDelete(tx *gorm.DB) (err error) {}
+
+// AfterDelete 更新双方的粉丝数和关注数
+func (follow *Follow) AfterDelete(tx *gorm.DB) (err error) {
+	if len(follow.FollowerID) == 0 {
+		return nil
+	}
+	return user.RefreshFollowStats(tx, follow.FollowerID, follow.FollowingID)
+}
+
+// func (follow *Follow) AfterFind(tx *gorm.DB) (err error) {}
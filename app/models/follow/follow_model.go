@@ -0,0 +1,15 @@
+package follow
+
+import (
+	"gohub/app/models"
+)
+
+// Follow 关注关系，FollowerID 关注了 FollowingID（follower_id + following_id 唯一）
+type Follow struct {
+	models.BaseModel
+
+	FollowerID  string `json:"follower_id,omitempty"`
+	FollowingID string `json:"following_id,omitempty"`
+
+	models.CommonTimestampsField
+}
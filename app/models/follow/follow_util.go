@@ -0,0 +1,126 @@
+package follow
+
+import (
+	"gohub/app/models/user"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/feed"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// IsFollowing followerID 是否关注了 followingID
+func IsFollowing(followerID, followingID string) bool {
+	var count int64
+	database.DB.Model(Follow{}).
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Count(&count)
+	return count > 0
+}
+
+// Add 关注用户，已关注时不做任何处理
+// 新关注的用户最近的动态回填到时间线，回填失败只记录日志
+func Add(followerID, followingID string) error {
+	follow := Follow{FollowerID: followerID, FollowingID: followingID}
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "follower_id"}, {Name: "following_id"}},
+		DoNothing: true,
+	}).Create(&follow).Error
+	if err != nil {
+		return err
+	}
+	logger.LogIf(feed.NewFeed().Backfill(followerID, followingID, config.GetInt("feed.backfill")))
+	return nil
+}
+
+// Remove 取消关注，未关注时不做任何处理
+// 时间线中已有的动态不做清理，读取时间线时会过滤掉未关注用户的动态
+func Remove(followerID, followingID string) error {
+	return database.DB.
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Delete(&Follow{FollowerID: followerID, FollowingID: followingID}).
+		Error
+}
+
+// FollowerIDs 用户的所有粉丝 ID
+func FollowerIDs(userID string) (ids []string) {
+	database.DB.Model(Follow{}).Where("following_id = ?", userID).Pluck("follower_id", &ids)
+	return
+}
+
+// FollowingIDs 用户关注的所有用户 ID
+func FollowingIDs(userID string) (ids []string) {
+	database.DB.Model(Follow{}).Where("follower_id = ?", userID).Pluck("following_id", &ids)
+	return
+}
+
+// PaginateFollowers 用户的粉丝分页
+func PaginateFollowers(c *gin.Context, userID string, perPage int) (users []user.User, paging paginator.Page) {
+	subQuery := database.DB.Model(Follow{}).Select("follower_id").Where("following_id = ?", userID)
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(user.User{}).Where("id IN (?)", subQuery),
+		&users,
+		app.V1URL("users/"+userID+"/followers"),
+		perPage,
+	)
+	return
+}
+
+// PaginateFollowings 用户关注的用户分页
+func PaginateFollowings(c *gin.Context, userID string, perPage int) (users []user.User, paging paginator.Page) {
+	subQuery := database.DB.Model(Follow{}).Select("following_id").Where("follower_id = ?", userID)
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(user.User{}).Where("id IN (?)", subQuery),
+		&users,
+		app.V1URL("users/"+userID+"/followings"),
+		perPage,
+	)
+	return
+}
+
+// PublishActivity 发布动态：作者的粉丝数未超过 feed.fanout_threshold 时写入所有粉丝的时间线（推模式），
+// 否则只写入作者的发件箱，粉丝读取时间线时合并（拉模式），发布失败只记录日志
+func PublishActivity(activity feed.Activity) {
+	var followerIDs []string
+	actor := user.Get(activity.ActorID)
+	if actor.FollowersCount <= config.GetInt64("feed.fanout_threshold") {
+		followerIDs = FollowerIDs(activity.ActorID)
+	}
+	logger.LogIf(feed.NewFeed().Publish(activity, followerIDs))
+}
+
+// Timeline 用户的时间线，before 和 next 的说明见 feed.Timeline
+// 只返回当前仍在关注的用户的动态，取消关注后时间线中的旧动态随之不再显示
+func Timeline(userID string, before int64, limit int) ([]feed.Activity, int64, error) {
+	followingIDs := FollowingIDs(userID)
+	following := make(map[string]bool, len(followingIDs))
+	for _, id := range followingIDs {
+		following[id] = true
+	}
+
+	// 粉丝数超过阈值的用户不推送动态，从其发件箱拉取
+	var pullActorIDs []string
+	if len(followingIDs) > 0 {
+		database.DB.Model(user.User{}).
+			Where("id IN ? AND followers_count > ?", followingIDs, config.GetInt64("feed.fanout_threshold")).
+			Pluck("id", &pullActorIDs)
+	}
+
+	activities, next, err := feed.NewFeed().Timeline(userID, pullActorIDs, before, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	filtered := activities[:0]
+	for _, activity := range activities {
+		if following[activity.ActorID] {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered, next, nil
+}
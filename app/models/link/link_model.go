@@ -13,6 +13,7 @@ type Link struct {
 	URL  string `json:"url,omitempty"`
 
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 func (link *Link) Create() {
@@ -24,7 +25,20 @@ func (link *Link) Save() (rowsAffected int64) {
 	return result.RowsAffected
 }
 
+// Delete 软删除，数据移入回收站
 func (link *Link) Delete() (rowsAffected int64) {
 	result := database.DB.Delete(&link)
 	return result.RowsAffected
 }
+
+// Restore 从回收站恢复
+func (link *Link) Restore() (rowsAffected int64) {
+	result := database.DB.Unscoped().Model(&link).Update("deleted_at", nil)
+	return result.RowsAffected
+}
+
+// ForceDelete 彻底删除，无法恢复
+func (link *Link) ForceDelete() (rowsAffected int64) {
+	result := database.DB.Unscoped().Delete(&link)
+	return result.RowsAffected
+}
@@ -43,9 +43,12 @@ func Paginate(c *gin.Context, perPage int) (links []Link, paging paginator.Page)
 	return
 }
 
+// allCachedKey AllCached 使用的缓存 key
+const allCachedKey = "links:all"
+
 func AllCached() (links []Link) {
 	// 设置缓存 key
-	cacheKey := "links:all"
+	cacheKey := allCachedKey
 	// 取数据
 	cache.GetObject(cacheKey, &links)
 
@@ -63,3 +66,32 @@ func AllCached() (links []Link) {
 	}
 	return
 }
+
+// ForgetCached 清除 AllCached 的缓存，链接删除或恢复后调用
+func ForgetCached() {
+	cache.Forget(allCachedKey)
+}
+
+// GetWithTrashed 通过 ID 获取，包含回收站中的数据
+func GetWithTrashed(idstr string) (link Link) {
+	database.DB.Unscoped().Where("id = ?", idstr).First(&link)
+	return
+}
+
+// GetTrashed 通过 ID 获取回收站中的数据
+func GetTrashed(idstr string) (link Link) {
+	database.DB.Unscoped().Where("id = ?", idstr).Where("deleted_at IS NOT NULL").First(&link)
+	return
+}
+
+// PaginateTrashed 回收站分页内容
+func PaginateTrashed(c *gin.Context, perPage int) (links []Link, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Unscoped().Model(Link{}).Where("deleted_at IS NOT NULL"),
+		&links,
+		app.V1URL("trash/"+database.TableName(&Link{})),
+		perPage,
+	)
+	return
+}
@@ -0,0 +1,28 @@
+package message
+
+import (
+	"gohub/app/models/conversation"
+	"gohub/pkg/filter"
+
+	"gorm.io/gorm"
+)
+
+// BeforeSave 替换 mask 模式的敏感词
+func (message *Message) BeforeSave(tx *gorm.DB) (err error) {
+	message.Body = filter.NewFilter().Mask(message.Body)
+	return nil
+}
+
+// func (message *Message) BeforeCreate(tx *gorm.DB) (err error) {}
+
+// AfterCreate 更新双方会话的最后一条消息和未读数
+func (message *Message) AfterCreate(tx *gorm.DB) (err error) {
+	return conversation.RefreshStats(tx, message.SenderID, message.RecipientID)
+}
+
+// func (message *Message) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (message *Message) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (message *Message) AfterSave(tx *gorm.DB) (err error) {}
+// func (message *Message) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (message *Message) AfterDelete(tx *gorm.DB) (err error) {}
+// func (message *Message) AfterFind(tx *gorm.DB) (err error) {}
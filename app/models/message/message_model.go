@@ -0,0 +1,25 @@
+package message
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+)
+
+// Message 私信消息，sender_id 发送给 recipient_id，双方的会话见 conversation.Conversation
+type Message struct {
+	models.BaseModel
+
+	SenderID    string `json:"sender_id,omitempty"`
+	RecipientID string `json:"recipient_id,omitempty"`
+	Body        string `json:"body,omitempty"`
+
+	// 通过 sender_id 关联发送人
+	Sender user.User `gorm:"foreignKey:SenderID" json:"sender"`
+
+	models.CommonTimestampsField
+}
+
+func (message *Message) Create() {
+	database.DB.Create(&message)
+}
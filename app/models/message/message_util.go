@@ -0,0 +1,30 @@
+package message
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Get(idstr string) (message Message) {
+	database.DB.Preload("Sender").Where("id", idstr).First(&message)
+	return
+}
+
+// PaginateBetween 两个用户之间的消息分页，baseURL 为会话的消息列表链接
+func PaginateBetween(c *gin.Context, userID, peerID, baseURL string, perPage int) (messages []Message, paging paginator.Page) {
+	query := database.DB.Model(Message{}).Where(
+		"(sender_id = ? AND recipient_id = ?) OR (sender_id = ? AND recipient_id = ?)",
+		userID, peerID, peerID, userID,
+	)
+	paging = paginator.Paginate(
+		c,
+		query,
+		&messages,
+		app.V1URL(baseURL),
+		perPage,
+	)
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/spf13/cast"
+	"gorm.io/gorm"
 )
 
 // BaseModel 模型基类
@@ -17,6 +18,17 @@ type CommonTimestampsField struct {
 	UpdatedAt time.Time `gorm:"column:updated_at;index;" json:"updated_at,omitempty"`
 }
 
+// SoftDeletes 软删除，删除时只写入 deleted_at，查询时默认排除已删除的数据
+// 需要包含已删除数据时使用 database.DB.Unscoped()
+type SoftDeletes struct {
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;" json:"deleted_at,omitempty"`
+}
+
+// Trashed 是否已被软删除
+func (s *SoftDeletes) Trashed() bool {
+	return s.DeletedAt.Valid
+}
+
 // GetStringID 获取 ID 的字符串格式
 func (b *BaseModel) GetStringID() string {
 	return cast.ToString(b.ID)
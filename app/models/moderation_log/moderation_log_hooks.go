@@ -0,0 +1,11 @@
+package moderation_log
+
+// func (moderationLog *ModerationLog) BeforeSave(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) AfterCreate(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) AfterSave(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) AfterDelete(tx *gorm.DB) (err error) {}
+// func (moderationLog *ModerationLog) AfterFind(tx *gorm.DB) (err error) {}
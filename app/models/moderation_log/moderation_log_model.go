@@ -0,0 +1,37 @@
+package moderation_log
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+)
+
+// 处理操作
+const (
+	ActionHide     = "hide"      // 举报成立，隐藏话题（移入回收站）
+	ActionResolve  = "resolve"   // 举报成立，已通过其他方式处理，不隐藏话题
+	ActionDismiss  = "dismiss"   // 举报不成立，自动隐藏的话题会被恢复
+	ActionAutoHide = "auto_hide" // 独立举报人数达到阈值，系统自动隐藏话题
+)
+
+// ModerationLog 版主处理举报的审计记录，只增不改，话题被彻底删除后仍然保留
+type ModerationLog struct {
+	models.BaseModel
+
+	// 处理人，系统自动处理时为 0
+	ModeratorID string `json:"moderator_id"`
+	TopicID     string `json:"topic_id,omitempty"`
+	Action      string `json:"action,omitempty"`
+	// 本次处理的举报数
+	ReportCount int64  `json:"report_count"`
+	Note        string `json:"note,omitempty"`
+
+	// 通过 moderator_id 关联处理人
+	Moderator user.User `gorm:"foreignKey:ModeratorID" json:"moderator"`
+
+	models.CommonTimestampsField
+}
+
+func (moderationLog *ModerationLog) Create() {
+	database.DB.Create(&moderationLog)
+}
@@ -0,0 +1,33 @@
+package moderation_log
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LastByTopic 话题最近的一条处理记录
+func LastByTopic(topicID string) (moderationLog ModerationLog) {
+	database.DB.Where("topic_id = ?", topicID).Order("id DESC").First(&moderationLog)
+	return
+}
+
+// Filters 处理记录允许的过滤条件，参数格式在 requests.ModerationLogFilter 中验证
+var Filters = paginator.Filters{
+	{Param: "topic_id"},
+	{Param: "moderator_id"},
+	{Param: "action"},
+}
+
+func Paginate(c *gin.Context, perPage int) (moderationLogs []ModerationLog, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		Filters.Apply(c, database.DB.Model(ModerationLog{})),
+		&moderationLogs,
+		app.V1URL("moderation/logs"),
+		perPage,
+	)
+	return
+}
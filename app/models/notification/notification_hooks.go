@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"encoding/json"
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// func (notification *Notification) BeforeSave(tx *gorm.DB) (err error) {}
+// func (notification *Notification) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (notification *Notification) AfterCreate(tx *gorm.DB) (err error) {}
+// func (notification *Notification) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (notification *Notification) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (notification *Notification) AfterSave(tx *gorm.DB) (err error) {}
+// func (notification *Notification) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (notification *Notification) AfterDelete(tx *gorm.DB) (err error) {}
+
+// AfterFind 将通知内容原样输出为 JSON 对象
+func (notification *Notification) AfterFind(tx *gorm.DB) (err error) {
+	if json.Valid([]byte(notification.Data)) {
+		notification.Payload = json.RawMessage(notification.Data)
+	}
+	return nil
+}
+
+// deleteByUser 彻底删除用户时删除其收到的通知
+func deleteByUser(tx *gorm.DB, userID string) error {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Exec("DELETE FROM notifications WHERE user_id = ?", userID).
+		Error
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"encoding/json"
+	"gohub/app/models"
+	"gohub/pkg/database"
+	"time"
+)
+
+// Notification 站内信，由 notify.Database 渠道写入
+type Notification struct {
+	models.BaseModel
+
+	UserID string `json:"-"`
+	// 通知类型，如 topic_replied，不同类型的内容字段见 app/notifications
+	Type string `json:"type"`
+	// 通知内容的 JSON，读取时由 AfterFind 解析到 Payload
+	Data    string          `json:"-"`
+	Payload json.RawMessage `gorm:"-" json:"data"`
+	// 已读时间，为空表示未读
+	ReadAt *time.Time `json:"read_at"`
+
+	models.CommonTimestampsField
+}
+
+// IsRead 是否已读
+func (notification *Notification) IsRead() bool {
+	return notification.ReadAt != nil
+}
+
+// MarkAsRead 标记为已读，已读的通知不做处理
+func (notification *Notification) MarkAsRead() (rowsAffected int64) {
+	if notification.IsRead() {
+		return 0
+	}
+	now := time.Now()
+	result := database.DB.Model(&notification).Update("read_at", now)
+	if result.RowsAffected > 0 {
+		notification.ReadAt = &now
+	}
+	return result.RowsAffected
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetByUser 获取用户的某条通知
+func GetByUser(userID, idstr string) (notification Notification) {
+	database.DB.Where("user_id = ? AND id = ?", userID, idstr).First(&notification)
+	return
+}
+
+// Paginate 用户的通知分页，unreadOnly 为 true 时只返回未读通知
+func Paginate(c *gin.Context, userID string, unreadOnly bool, perPage int) (notifications []Notification, paging paginator.Page) {
+	query := database.DB.Model(Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	paging = paginator.Paginate(
+		c,
+		query,
+		&notifications,
+		app.V1URL(database.TableName(&Notification{})),
+		perPage,
+	)
+	return
+}
+
+// UnreadCount 用户的未读通知数
+func UnreadCount(userID string) (count int64) {
+	database.DB.Model(Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count)
+	return
+}
+
+// MarkAllAsRead 将用户的所有未读通知标记为已读，返回标记的条数
+func MarkAllAsRead(userID string) int64 {
+	result := database.DB.Model(Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected
+}
@@ -0,0 +1,28 @@
+package notification_preference
+
+import (
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// func (notificationPreference *NotificationPreference) BeforeSave(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) AfterCreate(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) AfterSave(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) AfterDelete(tx *gorm.DB) (err error) {}
+// func (notificationPreference *NotificationPreference) AfterFind(tx *gorm.DB) (err error) {}
+
+// deleteByUser 彻底删除用户时删除其通知设置
+func deleteByUser(tx *gorm.DB, userID string) error {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Exec("DELETE FROM notification_preferences WHERE user_id = ?", userID).
+		Error
+}
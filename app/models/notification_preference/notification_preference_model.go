@@ -0,0 +1,17 @@
+package notification_preference
+
+import (
+	"gohub/app/models"
+)
+
+// NotificationPreference 用户对某类通知的接收渠道，未设置时使用 notify.defaults 配置
+type NotificationPreference struct {
+	models.BaseModel
+
+	UserID string `json:"-"`
+	Type   string `json:"type"`
+	// 接收渠道，多个以逗号分隔，为空表示不接收此类通知
+	Channels string `json:"channels"`
+
+	models.CommonTimestampsField
+}
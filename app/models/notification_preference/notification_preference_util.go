@@ -0,0 +1,70 @@
+package notification_preference
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Types 所有的通知类型，即 notify.defaults 配置中的类型
+func Types() []string {
+	var types []string
+	for t := range config.GetStringMapString("notify.defaults") {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Channels 用户接收某类通知的渠道
+func Channels(userID, notificationType string) []string {
+	var preference NotificationPreference
+	database.DB.Where("user_id = ? AND type = ?", userID, notificationType).First(&preference)
+	if preference.ID == 0 {
+		return splitChannels(config.GetString("notify.defaults." + notificationType))
+	}
+	return splitChannels(preference.Channels)
+}
+
+// AllForUser 用户所有类型通知的接收渠道，key 为通知类型
+func AllForUser(userID string) map[string][]string {
+	result := make(map[string][]string)
+	for t, channels := range config.GetStringMapString("notify.defaults") {
+		result[t] = splitChannels(channels)
+	}
+
+	var preferences []NotificationPreference
+	database.DB.Where("user_id = ?", userID).Find(&preferences)
+	for _, preference := range preferences {
+		if _, ok := result[preference.Type]; ok {
+			result[preference.Type] = splitChannels(preference.Channels)
+		}
+	}
+	return result
+}
+
+// Set 设置用户接收某类通知的渠道，channels 为空表示不接收
+func Set(userID, notificationType string, channels []string) error {
+	preference := NotificationPreference{
+		UserID:   userID,
+		Type:     notificationType,
+		Channels: strings.Join(channels, ","),
+	}
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channels", "updated_at"}),
+	}).Create(&preference).Error
+}
+
+func splitChannels(channels string) []string {
+	result := []string{}
+	for _, channel := range strings.Split(channels, ",") {
+		if channel = strings.TrimSpace(channel); len(channel) > 0 {
+			result = append(result, channel)
+		}
+	}
+	return result
+}
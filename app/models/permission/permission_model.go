@@ -0,0 +1,30 @@
+package permission
+
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+)
+
+// Permission 权限，名称使用 资源.操作 的格式，如 category.delete
+type Permission struct {
+	models.BaseModel
+
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (permission *Permission) Create() {
+	database.DB.Create(&permission)
+}
+
+func (permission *Permission) Save() (rowsAffected int64) {
+	result := database.DB.Save(&permission)
+	return result.RowsAffected
+}
+
+func (permission *Permission) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&permission)
+	return result.RowsAffected
+}
@@ -0,0 +1,31 @@
+package permission
+
+import (
+	"gohub/pkg/database"
+)
+
+func Get(idstr string) (permission Permission) {
+	database.DB.Where("id", idstr).First(&permission)
+	return
+}
+
+func GetByName(name string) (permission Permission) {
+	database.DB.Where("name = ?", name).First(&permission)
+	return
+}
+
+func All() (permissions []Permission) {
+	database.DB.Find(&permissions)
+	return
+}
+
+// NamesByUser 获取用户通过角色获得的所有权限名称
+func NamesByUser(userID string) (names []string) {
+	database.DB.Model(Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("permissions.name", &names)
+	return
+}
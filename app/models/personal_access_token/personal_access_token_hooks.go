@@ -0,0 +1,11 @@
+package personal_access_token
+
+// func (personalAccessToken *PersonalAccessToken) BeforeSave(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) AfterCreate(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) AfterSave(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) AfterDelete(tx *gorm.DB) (err error) {}
+// func (personalAccessToken *PersonalAccessToken) AfterFind(tx *gorm.DB) (err error) {}
\ No newline at end of file
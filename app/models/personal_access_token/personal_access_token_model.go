@@ -0,0 +1,68 @@
+package personal_access_token
+
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+	"gohub/pkg/helpers"
+	"strings"
+	"time"
+)
+
+// PersonalAccessToken 个人访问令牌，供脚本、CI 等机器客户端使用，数据库只保存令牌的 SHA-256
+type PersonalAccessToken struct {
+	models.BaseModel
+
+	UserID     string     `json:"-"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // 令牌的开头几位，便于用户辨认
+	Token      string     `json:"-"`
+	Scopes     string     `json:"scopes"` // 逗号分隔
+	RateLimit  string     `json:"rate_limit"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (personalAccessToken *PersonalAccessToken) Create() {
+	database.DB.Create(&personalAccessToken)
+}
+
+func (personalAccessToken *PersonalAccessToken) Save() (rowsAffected int64) {
+	result := database.DB.Save(&personalAccessToken)
+	return result.RowsAffected
+}
+
+func (personalAccessToken *PersonalAccessToken) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&personalAccessToken)
+	return result.RowsAffected
+}
+
+// ScopeList 令牌的作用域
+func (personalAccessToken *PersonalAccessToken) ScopeList() []string {
+	if len(personalAccessToken.Scopes) == 0 {
+		return []string{}
+	}
+	return strings.Split(personalAccessToken.Scopes, ",")
+}
+
+// Can 令牌是否拥有某个作用域
+func (personalAccessToken *PersonalAccessToken) Can(scope string) bool {
+	return helpers.InSlice(scope, personalAccessToken.ScopeList())
+}
+
+// IsExpired 令牌是否已过期，ExpiresAt 为空表示永不过期
+func (personalAccessToken *PersonalAccessToken) IsExpired() bool {
+	return personalAccessToken.ExpiresAt != nil && personalAccessToken.ExpiresAt.Before(time.Now())
+}
+
+// Touch 记录最后使用时间和 IP，只更新这两个字段
+func (personalAccessToken *PersonalAccessToken) Touch(usedAt time.Time, ip string) {
+	personalAccessToken.LastUsedAt = &usedAt
+	personalAccessToken.LastUsedIP = ip
+	database.DB.Model(personalAccessToken).UpdateColumns(map[string]interface{}{
+		"last_used_at": usedAt,
+		"last_used_ip": ip,
+	})
+}
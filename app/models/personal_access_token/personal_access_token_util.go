@@ -0,0 +1,28 @@
+package personal_access_token
+
+import (
+	"gohub/pkg/database"
+)
+
+func Get(idstr string) (personalAccessToken PersonalAccessToken) {
+	database.DB.Where("id", idstr).First(&personalAccessToken)
+	return
+}
+
+// GetByToken 通过令牌的哈希值获取
+func GetByToken(hashed string) (personalAccessToken PersonalAccessToken) {
+	database.DB.Where("token = ?", hashed).First(&personalAccessToken)
+	return
+}
+
+// GetByUser 获取用户的某个令牌，用于确认令牌属于当前用户
+func GetByUser(userID, idstr string) (personalAccessToken PersonalAccessToken) {
+	database.DB.Where("user_id = ? AND id = ?", userID, idstr).First(&personalAccessToken)
+	return
+}
+
+// AllByUser 获取用户的所有令牌，最新创建的在前
+func AllByUser(userID string) (personalAccessTokens []PersonalAccessToken) {
+	database.DB.Where("user_id = ?", userID).Order("id desc").Find(&personalAccessTokens)
+	return
+}
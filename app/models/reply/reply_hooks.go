@@ -0,0 +1,72 @@
+package reply
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/filter"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// BeforeSave 替换 mask 模式的敏感词
+func (reply *Reply) BeforeSave(tx *gorm.DB) (err error) {
+	reply.Body = filter.NewFilter().Mask(reply.Body)
+	return nil
+}
+
+// func (reply *Reply) BeforeCreate(tx *gorm.DB) (err error) {}
+
+// AfterCreate 更新话题的回复数和最后回复时间
+func (reply *Reply) AfterCreate(tx *gorm.DB) (err error) {
+	return topic.RefreshReplyStats(tx, reply.TopicID)
+}
+
+// func (reply *Reply) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (reply *Reply) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (reply *Reply) AfterSave(tx *gorm.DB) (err error) {}
+// func (reply *Reply) BeforeDelete(tx *gorm.DB) (err error) {}
+
+// AfterDelete 更新话题的回复数和最后回复时间，批量删除子回复时没有 TopicID，由删除父回复时统一更新
+func (reply *Reply) AfterDelete(tx *gorm.DB) (err error) {
+	if len(reply.TopicID) == 0 {
+		return nil
+	}
+	return topic.RefreshReplyStats(tx, reply.TopicID)
+}
+
+// func (reply *Reply) AfterFind(tx *gorm.DB) (err error) {}
+
+// deleteByUser 彻底删除用户时删除其所有回复（包括回收站中的）和这些回复下的子回复，并重新统计相关话题的回复数
+func deleteByUser(tx *gorm.DB, userID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var replies []Reply
+	if err := db.Unscoped().Where("user_id = ?", userID).Find(&replies).Error; err != nil {
+		return err
+	}
+	if len(replies) == 0 {
+		return nil
+	}
+
+	var ids []uint64
+	topicIDs := make(map[string]bool)
+	for i := range replies {
+		ids = append(ids, replies[i].ID)
+		ids = append(ids, descendantIDs(db.Unscoped(), &replies[i])...)
+		topicIDs[replies[i].TopicID] = true
+	}
+	// 批量删除时没有 TopicID，AfterDelete 不会重复统计
+	if err := db.Unscoped().Where("id IN ?", ids).Delete(&Reply{}).Error; err != nil {
+		return err
+	}
+	for topicID := range topicIDs {
+		if err := topic.RefreshReplyStats(tx, topicID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package reply
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+	"gohub/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+type Reply struct {
+	models.BaseModel
+
+	TopicID string `json:"topic_id,omitempty"`
+	UserID  string `json:"user_id,omitempty"`
+	// 父回复 ID，直接回复话题时为 0
+	ParentID string `gorm:"default:0" json:"parent_id,omitempty"`
+	// 所在楼层（第 1 层回复）的 ID，第 1 层回复本身为 0
+	RootID string `gorm:"default:0" json:"root_id,omitempty"`
+	// 嵌套层级，第 1 层回复为 1
+	Depth int    `json:"depth"`
+	Body  string `json:"body,omitempty"`
+
+	// 通过 user_id 关联用户
+	User user.User `json:"user"`
+	// 子回复，由 LoadChildren 填充
+	Children []Reply `gorm:"-" json:"children,omitempty"`
+
+	models.CommonTimestampsField
+	models.SoftDeletes
+}
+
+func (reply *Reply) Create() {
+	database.DB.Create(&reply)
+}
+
+func (reply *Reply) Save() (rowsAffected int64) {
+	result := database.DB.Save(&reply)
+	return result.RowsAffected
+}
+
+// Delete 软删除回复，其下的所有子回复一并删除
+func (reply *Reply) Delete() (rowsAffected int64) {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if ids := descendantIDs(tx, reply); len(ids) > 0 {
+			if err := tx.Where("id IN ?", ids).Delete(&Reply{}).Error; err != nil {
+				return err
+			}
+		}
+		// 最后删除自身，AfterDelete 钩子重新统计话题的回复数
+		result := tx.Delete(&reply)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		logger.LogIf(err)
+		return 0
+	}
+	return
+}
+
+// IsRoot 是否为第 1 层回复
+func (reply *Reply) IsRoot() bool {
+	return reply.ParentID == "" || reply.ParentID == "0"
+}
@@ -0,0 +1,96 @@
+package reply
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Get(idstr string) (reply Reply) {
+	database.DB.Preload("User").Where("id", idstr).First(&reply)
+	return
+}
+
+// GetByIDs 通过 ID 批量获取，不保证顺序
+func GetByIDs(ids []string) (replies []Reply) {
+	if len(ids) == 0 {
+		return
+	}
+	database.DB.Where("id IN ?", ids).Find(&replies)
+	return
+}
+
+// GetInTopic 获取话题下的某条回复
+func GetInTopic(topicID, idstr string) (reply Reply) {
+	database.DB.Preload("User").Where("topic_id = ? AND id = ?", topicID, idstr).First(&reply)
+	return
+}
+
+// Paginate 话题的第 1 层回复分页，子回复需调用 LoadChildren 加载
+func Paginate(c *gin.Context, topicID string, perPage int) (replies []Reply, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(Reply{}).Where("topic_id = ? AND parent_id = 0", topicID),
+		&replies,
+		app.V1URL("topics/"+topicID+"/replies"),
+		perPage,
+	)
+	return
+}
+
+// LoadChildren 加载第 1 层回复下的所有子回复，组装成树形结构
+func LoadChildren(roots []Reply) []Reply {
+	if len(roots) == 0 {
+		return roots
+	}
+
+	rootIDs := make([]uint64, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.ID)
+	}
+	var descendants []Reply
+	database.DB.Preload("User").Where("root_id IN ?", rootIDs).Order("id asc").Find(&descendants)
+
+	// 按父回复分组，再从第 1 层开始递归组装
+	children := make(map[string][]Reply)
+	for _, r := range descendants {
+		children[r.ParentID] = append(children[r.ParentID], r)
+	}
+	var attach func(replies []Reply) []Reply
+	attach = func(replies []Reply) []Reply {
+		for i := range replies {
+			if kids, ok := children[replies[i].GetStringID()]; ok {
+				replies[i].Children = attach(kids)
+			}
+		}
+		return replies
+	}
+	return attach(roots)
+}
+
+// descendantIDs 获取回复下所有子回复的 ID
+func descendantIDs(tx *gorm.DB, reply *Reply) (ids []uint64) {
+	var candidates []Reply
+	if reply.IsRoot() {
+		tx.Select("id", "parent_id").Where("root_id = ?", reply.ID).Find(&candidates)
+	} else {
+		tx.Select("id", "parent_id").Where("root_id = ? AND depth > ?", reply.RootID, reply.Depth).Find(&candidates)
+	}
+
+	// 从当前回复开始逐层向下查找
+	parents := map[string]bool{reply.GetStringID(): true}
+	for found := true; found; {
+		found = false
+		for _, r := range candidates {
+			if parents[r.ParentID] && !parents[r.GetStringID()] {
+				parents[r.GetStringID()] = true
+				ids = append(ids, r.ID)
+				found = true
+			}
+		}
+	}
+	return
+}
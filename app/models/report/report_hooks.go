@@ -0,0 +1,28 @@
+package report
+
+import (
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// func (report *Report) BeforeSave(tx *gorm.DB) (err error) {}
+// func (report *Report) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (report *Report) AfterCreate(tx *gorm.DB) (err error) {}
+// func (report *Report) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (report *Report) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (report *Report) AfterSave(tx *gorm.DB) (err error) {}
+// func (report *Report) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (report *Report) AfterDelete(tx *gorm.DB) (err error) {}
+// func (report *Report) AfterFind(tx *gorm.DB) (err error) {}
+
+// deleteByUser 彻底删除用户时删除其提交的举报，处理记录作为审计日志保留
+func deleteByUser(tx *gorm.DB, userID string) error {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Exec("DELETE FROM reports WHERE reporter_id = ?", userID).
+		Error
+}
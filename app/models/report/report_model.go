@@ -0,0 +1,64 @@
+package report
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+	"time"
+)
+
+// 举报原因
+const (
+	ReasonSpam     = "spam"      // 垃圾广告
+	ReasonAbuse    = "abuse"     // 辱骂、人身攻击
+	ReasonPorn     = "porn"      // 色情低俗
+	ReasonIllegal  = "illegal"   // 违法违规
+	ReasonOffTopic = "off_topic" // 与社区主题无关
+	ReasonOther    = "other"     // 其他，需填写说明
+)
+
+// Reasons 所有的举报原因
+var Reasons = []string{ReasonSpam, ReasonAbuse, ReasonPorn, ReasonIllegal, ReasonOffTopic, ReasonOther}
+
+// 举报的处理状态
+const (
+	StatusPending   = "pending"   // 待处理
+	StatusResolved  = "resolved"  // 举报成立
+	StatusDismissed = "dismissed" // 举报不成立
+)
+
+// ReporterSystem 内容过滤命中 review 模式的敏感词时，以系统身份提交举报，进入审核队列
+const ReporterSystem = "0"
+
+// Report 用户对话题的举报，每个用户对每个话题只能举报一次（reporter_id + topic_id 唯一）
+type Report struct {
+	models.BaseModel
+
+	ReporterID  string `json:"reporter_id,omitempty"`
+	TopicID     string `json:"topic_id,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	// 版主处理的时间，处理记录见 moderation_log
+	HandledAt *time.Time `json:"handled_at,omitempty"`
+	// 话题在处理后被修改，同一用户再次举报而重新打开，版主再次处理前不计入自动隐藏的举报人数
+	Reopened bool `json:"reopened"`
+
+	// 通过 reporter_id 关联举报人
+	Reporter user.User `gorm:"foreignKey:ReporterID" json:"reporter"`
+	// 被举报的话题，可能已被隐藏（移入回收站），因此不通过关联读取，见 LoadTopics
+	Topic *topic.Topic `gorm:"-" json:"topic,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (report *Report) Save() (rowsAffected int64) {
+	result := database.DB.Save(&report)
+	return result.RowsAffected
+}
+
+func (report *Report) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&report)
+	return result.RowsAffected
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"gohub/app/models/topic"
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+func Get(idstr string) (report Report) {
+	database.DB.Where("id", idstr).First(&report)
+	return
+}
+
+// Add 提交举报，同一用户对同一话题只记录一次举报，report 填充为已有的举报，created 为 false
+// 已有的举报处理过、且话题在处理之后被修改过时，重新打开为待处理并更新举报原因，created 为 true
+// 话题未修改时版主的处理结果不变，重复举报不会重新打开
+func Add(report *Report) (created bool, err error) {
+	report.Status = StatusPending
+	result := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reporter_id"}, {Name: "topic_id"}},
+		DoNothing: true,
+	}).Create(report)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// 话题的修订记录在标题或正文变化时写入，以此判断处理之后是否修改过；条件更新保证并发提交时只有一个请求成功
+	result = database.DB.Model(Report{}).
+		Where("reporter_id = ? AND topic_id = ? AND status <> ?", report.ReporterID, report.TopicID, StatusPending).
+		Where("EXISTS (SELECT 1 FROM topic_revisions WHERE topic_revisions.topic_id = reports.topic_id AND topic_revisions.created_at > reports.handled_at)").
+		Updates(map[string]interface{}{
+			"status":      StatusPending,
+			"reason":      report.Reason,
+			"description": report.Description,
+			"handled_at":  nil,
+			"reopened":    true,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	reporterID, topicID := report.ReporterID, report.TopicID
+	*report = Report{}
+	err = database.DB.Where("reporter_id = ? AND topic_id = ?", reporterID, topicID).
+		First(report).
+		Error
+	return result.RowsAffected > 0, err
+}
+
+// PendingReporterCount 话题待处理举报的独立举报人数，不包括系统提交的举报和重新打开的举报
+func PendingReporterCount(topicID string) (count int64) {
+	database.DB.Model(Report{}).
+		Where("topic_id = ? AND status = ? AND reporter_id <> ? AND reopened = ?", topicID, StatusPending, ReporterSystem, false).
+		Distinct("reporter_id").
+		Count(&count)
+	return
+}
+
+// HandlePending 将话题所有待处理的举报标记为 status，返回处理的举报数
+func HandlePending(topicID, status string) (int64, error) {
+	result := database.DB.Model(Report{}).
+		Where("topic_id = ? AND status = ?", topicID, StatusPending).
+		Updates(map[string]interface{}{"status": status, "handled_at": time.Now(), "reopened": false})
+	return result.RowsAffected, result.Error
+}
+
+// Filters 举报列表允许的过滤条件，参数格式在 requests.ReportFilter 中验证
+var Filters = paginator.Filters{
+	{Param: "status"},
+	{Param: "reason"},
+	{Param: "topic_id"},
+}
+
+// Paginate 举报分页，默认按举报时间正序，即先处理最早的举报
+func Paginate(c *gin.Context, perPage int) (reports []Report, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		Filters.Apply(c, database.DB.Model(Report{})),
+		&reports,
+		app.V1URL("moderation/reports"),
+		perPage,
+	)
+	LoadTopics(reports)
+	return
+}
+
+// LoadTopics 读取举报的话题，包含已隐藏（在回收站中）的话题
+func LoadTopics(reports []Report) {
+	var ids []string
+	for _, r := range reports {
+		ids = append(ids, r.TopicID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	var topics []topic.Topic
+	database.DB.Unscoped().Preload(clause.Associations).Where("id IN ?", ids).Find(&topics)
+	byID := make(map[string]*topic.Topic, len(topics))
+	for i := range topics {
+		byID[topics[i].GetStringID()] = &topics[i]
+	}
+	for i := range reports {
+		reports[i].Topic = byID[reports[i].TopicID]
+	}
+}
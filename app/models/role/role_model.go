@@ -0,0 +1,54 @@
+package role
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/permission"
+	"gohub/pkg/database"
+)
+
+// Admin 管理员角色，拥有所有权限
+const Admin = "admin"
+
+type Role struct {
+	models.BaseModel
+
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// 通过 role_permissions 关联权限
+	Permissions []permission.Permission `gorm:"many2many:role_permissions;" json:"permissions,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (role *Role) Create() {
+	database.DB.Create(&role)
+}
+
+func (role *Role) Save() (rowsAffected int64) {
+	result := database.DB.Save(&role)
+	return result.RowsAffected
+}
+
+func (role *Role) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&role)
+	return result.RowsAffected
+}
+
+// AssignTo 将角色分配给用户，已分配时不做处理
+func (role *Role) AssignTo(userID string) bool {
+	if HasRole(userID, role.Name) {
+		return true
+	}
+	result := database.DB.Table("user_roles").Create(map[string]interface{}{
+		"user_id": userID,
+		"role_id": role.ID,
+	})
+	return result.Error == nil
+}
+
+// RemoveFrom 撤销用户的角色
+func (role *Role) RemoveFrom(userID string) (rowsAffected int64) {
+	result := database.DB.Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, role.ID)
+	return result.RowsAffected
+}
@@ -0,0 +1,39 @@
+package role
+
+import (
+	"gohub/pkg/database"
+)
+
+func Get(idstr string) (role Role) {
+	database.DB.Where("id", idstr).First(&role)
+	return
+}
+
+func GetByName(name string) (role Role) {
+	database.DB.Where("name = ?", name).First(&role)
+	return
+}
+
+func All() (roles []Role) {
+	database.DB.Preload("Permissions").Find(&roles)
+	return
+}
+
+// NamesByUser 获取用户的所有角色名称
+func NamesByUser(userID string) (names []string) {
+	database.DB.Model(Role{}).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("roles.name", &names)
+	return
+}
+
+// HasRole 判断用户是否拥有某个角色
+func HasRole(userID, name string) bool {
+	var count int64
+	database.DB.Model(Role{}).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ? AND roles.name = ?", userID, name).
+		Count(&count)
+	return count > 0
+}
@@ -0,0 +1,41 @@
+package tag
+
+import "gorm.io/gorm"
+
+// func (tag *Tag) BeforeSave(tx *gorm.DB) (err error) {}
+// func (tag *Tag) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (tag *Tag) AfterCreate(tx *gorm.DB) (err error) {}
+// func (tag *Tag) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (tag *Tag) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (tag *Tag) AfterSave(tx *gorm.DB) (err error) {}
+// func (tag *Tag) BeforeDelete(tx *gorm.DB) (err error) {}
+
+// AfterDelete 删除标签与话题的关联
+func (tag *Tag) AfterDelete(tx *gorm.DB) (err error) {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Exec("DELETE FROM topic_tags WHERE tag_id = ?", tag.ID).
+		Error
+}
+
+// func (tag *Tag) AfterFind(tx *gorm.DB) (err error) {}
+
+// RefreshTopicCount 重新统计标签下已发布且未删除的话题数，由 topic 的钩子和 SyncTags 调用
+// 话题软删除、恢复和彻底删除时都会重新统计
+func RefreshTopicCount(tx *gorm.DB, tagIDs ...string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+	for _, id := range tagIDs {
+		var count int64
+		err := db.Table("topic_tags").
+			Joins("JOIN topics ON topics.id = topic_tags.topic_id AND topics.deleted_at IS NULL AND topics.status = ?", "published").
+			Where("topic_tags.tag_id = ?", id).
+			Count(&count).
+			Error
+		if err != nil {
+			return err
+		}
+		if err := db.Table("tags").Where("id = ?", id).UpdateColumn("topic_count", count).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package tag
+
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+)
+
+// Tag 话题标签，与话题通过 topic_tags 表多对多关联
+type Tag struct {
+	models.BaseModel
+
+	// 规范化后的名称，见 Normalize
+	Name string `json:"name,omitempty"`
+	// URL 中使用的标识，唯一，见 Slug
+	Slug string `json:"slug,omitempty"`
+
+	// 未删除的话题数，由 RefreshTopicCount 维护，Save 时不会覆盖
+	TopicCount int64 `gorm:"<-:create" json:"topic_count"`
+
+	models.CommonTimestampsField
+}
+
+func (tag *Tag) Create() {
+	database.DB.Create(&tag)
+}
+
+func (tag *Tag) Save() (rowsAffected int64) {
+	result := database.DB.Save(&tag)
+	return result.RowsAffected
+}
+
+func (tag *Tag) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&tag)
+	return result.RowsAffected
+}
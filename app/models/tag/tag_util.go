@@ -0,0 +1,133 @@
+package tag
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/helpers"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm/clause"
+)
+
+func Get(idstr string) (tag Tag) {
+	database.DB.Where("id", idstr).First(&tag)
+	return
+}
+
+// GetBySlug 通过 URL 标识获取，slug 会先规范化，如 /tags/Golang 与 /tags/go 是同一个标签
+func GetBySlug(slug string) (tag Tag) {
+	slug = Slug(Normalize(slug))
+	if len(slug) == 0 {
+		return
+	}
+	database.DB.Where("slug = ?", slug).First(&tag)
+	return
+}
+
+// FindOrCreate 通过名称批量获取标签，不存在的标签自动创建，返回的顺序与 names 一致
+// 名称先规范化，规范化后相同的名称只保留一个，规范化后为空的名称忽略
+func FindOrCreate(names []string) ([]Tag, error) {
+	tags := make([]Tag, 0, len(names))
+	var slugs []string
+	for _, name := range names {
+		name = Normalize(name)
+		slug := Slug(name)
+		if len(slug) == 0 || helpers.InSlice(slug, slugs) {
+			continue
+		}
+		slugs = append(slugs, slug)
+		tags = append(tags, Tag{Name: name, Slug: slug})
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	// 已存在的标签保留原名称
+	err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []Tag
+	if err := database.DB.Where("slug IN ?", slugs).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	bySlug := make(map[string]Tag, len(existing))
+	for _, tag := range existing {
+		bySlug[tag.Slug] = tag
+	}
+	for i, slug := range slugs {
+		tags[i] = bySlug[slug]
+	}
+	return tags, nil
+}
+
+// Popular 话题数最多的标签
+func Popular(limit int) (tags []Tag) {
+	database.DB.Where("topic_count > 0").
+		Order("topic_count DESC").
+		Order("id").
+		Limit(limit).
+		Find(&tags)
+	return
+}
+
+// Autocomplete 输入标签时的自动补全，slug 以 query 开头的标签，话题数多的排在前面
+// 输入别名时，对应的标准标签排在最前面，如输入 golang 补全为 Go
+func Autocomplete(query string, limit int) (tags []Tag) {
+	slug := Slug(Normalize(query))
+	if len(slug) == 0 {
+		return
+	}
+	database.DB.Where("slug LIKE ?", slug+"%").
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "CASE WHEN slug = ? THEN 0 ELSE 1 END", Vars: []interface{}{slug}}}).
+		Order("topic_count DESC").
+		Order("id").
+		Limit(limit).
+		Find(&tags)
+	return
+}
+
+// Normalize 规范化标签名称：全角字符转半角，去除首尾空白和 # 号，合并连续的空白，
+// 最后按 tag.aliases 配置将别名转换为标准名称，别名不区分大小写
+func Normalize(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			return r - 0xFEE0
+		}
+		return r
+	}, name)
+	name = strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(name), "#")), " ")
+
+	if alias, ok := config.GetStringMapString("tag.aliases")[strings.ToLower(name)]; ok {
+		return alias
+	}
+	return name
+}
+
+// Slug 标签的 URL 标识：转为小写，字母、数字和 + . _ 保留，# 转为 sharp（如 C# 为 c-sharp），
+// 其他字符转为 -，中文标签的 slug 仍为中文
+func Slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+._", r):
+			b.WriteRune(r)
+		case r == '#':
+			b.WriteString("-sharp-")
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	// 合并连续的 -
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '-' })
+	return strings.Join(parts, "-")
+}
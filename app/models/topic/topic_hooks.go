@@ -1,11 +1,216 @@
 package topic
 
-// func (topic *Topic) BeforeSave(tx *gorm.DB) (err error) {}
-// func (topic *Topic) BeforeCreate(tx *gorm.DB) (err error) {}
+import (
+	"gohub/app/models/tag"
+	"gohub/app/models/topic_revision"
+	"gohub/pkg/filter"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BeforeSave 替换 mask 模式的敏感词，然后渲染正文，缓存到 body_html，避免每次读取时渲染
+func (topic *Topic) BeforeSave(tx *gorm.DB) (err error) {
+	topic.Title = filter.NewFilter().Mask(topic.Title)
+	topic.Body = filter.NewFilter().Mask(topic.Body)
+	topic.renderBody(tx)
+	return nil
+}
+
+// BeforeCreate 计算新话题的初始热度，直接发布的话题以创建时间作为发布时间
+func (topic *Topic) BeforeCreate(tx *gorm.DB) (err error) {
+	createdAt := topic.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	if topic.IsPublished() && topic.PublishedAt == nil {
+		topic.PublishedAt = &createdAt
+	}
+	topic.HotScore = HotScore(0, createdAt)
+	return nil
+}
+
 // func (topic *Topic) AfterCreate(tx *gorm.DB) (err error) {}
-// func (topic *Topic) BeforeUpdate(tx *gorm.DB) (err error) {}
+
+// BeforeUpdate 标题或正文有变化时记录修订历史，与话题的修改在同一事务中，记录失败时话题也不会修改
+// 在 BeforeSave 之后调用，记录的是替换敏感词后实际保存的内容
+func (topic *Topic) BeforeUpdate(tx *gorm.DB) (err error) {
+	// Update 修改单个字段时（如从回收站恢复）不会写入标题和正文，无需记录
+	if _, ok := tx.Statement.Dest.(map[string]interface{}); ok {
+		return nil
+	}
+
+	var current struct {
+		UserID    string
+		Title     string
+		Body      string
+		UpdatedAt time.Time
+	}
+	err = tx.Session(&gorm.Session{NewDB: true}).
+		Table("topics").
+		Select("user_id, title, body, updated_at").
+		Where("id = ?", topic.ID).
+		Take(&current).
+		Error
+	if err != nil {
+		return err
+	}
+	if current.Title == topic.Title && current.Body == topic.Body {
+		return nil
+	}
+
+	original := topic_revision.TopicRevision{
+		TopicID: topic.GetStringID(),
+		UserID:  current.UserID,
+		Title:   current.Title,
+		Body:    current.Body,
+	}
+	original.CreatedAt = current.UpdatedAt
+	revision := topic_revision.TopicRevision{
+		TopicID: topic.GetStringID(),
+		UserID:  topic.EditorID,
+		Title:   topic.Title,
+		Body:    topic.Body,
+		Note:    topic.EditNote,
+	}
+	if revision.UserID == "" {
+		revision.UserID = current.UserID
+	}
+	return topic_revision.Record(tx, original, revision)
+}
+
 // func (topic *Topic) AfterUpdate(tx *gorm.DB) (err error) {}
-// func (topic *Topic) AfterSave(tx *gorm.DB) (err error) {}
+
+// AfterSave 更新全文搜索索引，从回收站恢复时也会调用
+// 索引失败只记录日志，不影响话题的保存
+// 从回收站恢复时重新统计标签的话题数，草稿和定时发布的话题发布时才索引和统计
+func (topic *Topic) AfterSave(tx *gorm.DB) (err error) {
+	if topic.Trashed() || !topic.IsPublished() {
+		return nil
+	}
+	logger.LogIf(search.NewSearch().Index(tx, topic.SearchDocument()))
+	return tag.RefreshTopicCount(tx, topic.tagIDs(tx)...)
+}
+
 // func (topic *Topic) BeforeDelete(tx *gorm.DB) (err error) {}
-// func (topic *Topic) AfterDelete(tx *gorm.DB) (err error) {}
-// func (topic *Topic) AfterFind(tx *gorm.DB) (err error) {}
\ No newline at end of file
+
+// AfterDelete 从全文搜索索引中删除，并重新统计标签的话题数；
+// 话题被彻底删除时，一并删除其下的回复、投票、收藏、标签关联、举报和修订历史，软删除时保留，以便恢复
+func (topic *Topic) AfterDelete(tx *gorm.DB) (err error) {
+	logger.LogIf(search.NewSearch().Delete(tx, search.TypeTopic, topic.GetStringID()))
+	tagIDs := topic.tagIDs(tx)
+	if tx.Statement.Unscoped {
+		db := tx.Session(&gorm.Session{NewDB: true})
+		for _, table := range []string{"replies", "votes", "favorites", "topic_tags", "reports", "topic_revisions"} {
+			if err := db.Exec("DELETE FROM "+table+" WHERE topic_id = ?", topic.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return tag.RefreshTopicCount(tx, tagIDs...)
+}
+
+// func (topic *Topic) AfterFind(tx *gorm.DB) (err error) {}
+
+// tagIDs 话题所有标签的 ID
+func (topic *Topic) tagIDs(tx *gorm.DB) (ids []string) {
+	tx.Session(&gorm.Session{NewDB: true}).Table("topic_tags").Where("topic_id = ?", topic.ID).Pluck("tag_id", &ids)
+	return
+}
+
+// RefreshReplyStats 重新统计话题的回复数和最后回复时间，由 reply 的 AfterCreate 和 AfterDelete 钩子调用
+// 每次重新统计而不是加减计数，软删除、批量删除子回复后也能保持准确
+func RefreshReplyStats(tx *gorm.DB, topicID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var count int64
+	if err := db.Table("replies").Where("topic_id = ? AND deleted_at IS NULL", topicID).Count(&count).Error; err != nil {
+		return err
+	}
+	var lastReplyAt []time.Time
+	err := db.Table("replies").
+		Where("topic_id = ? AND deleted_at IS NULL", topicID).
+		Order("id desc").
+		Limit(1).
+		Pluck("created_at", &lastReplyAt).
+		Error
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]interface{}{"reply_count": count, "last_reply_at": nil}
+	if len(lastReplyAt) > 0 {
+		columns["last_reply_at"] = lastReplyAt[0]
+	}
+	if err := db.Table("topics").Where("id = ?", topicID).UpdateColumns(columns).Error; err != nil {
+		return err
+	}
+	return refreshHotScore(db, topicID)
+}
+
+// RefreshVoteStats 重新统计话题的赞成票和反对票数，由 vote 的 AfterCreate 和 AfterDelete 钩子调用
+func RefreshVoteStats(tx *gorm.DB, topicID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var upvotes, downvotes int64
+	if err := db.Table("votes").Where("topic_id = ? AND value > 0", topicID).Count(&upvotes).Error; err != nil {
+		return err
+	}
+	if err := db.Table("votes").Where("topic_id = ? AND value < 0", topicID).Count(&downvotes).Error; err != nil {
+		return err
+	}
+	err := db.Table("topics").Where("id = ?", topicID).UpdateColumns(map[string]interface{}{
+		"upvote_count":   upvotes,
+		"downvote_count": downvotes,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return refreshHotScore(db, topicID)
+}
+
+// RefreshFavoriteStats 重新统计话题的收藏数，由 favorite 的 AfterCreate 和 AfterDelete 钩子调用
+func RefreshFavoriteStats(tx *gorm.DB, topicID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var favorites int64
+	if err := db.Table("favorites").Where("topic_id = ?", topicID).Count(&favorites).Error; err != nil {
+		return err
+	}
+	if err := db.Table("topics").Where("id = ?", topicID).UpdateColumn("favorite_count", favorites).Error; err != nil {
+		return err
+	}
+	return refreshHotScore(db, topicID)
+}
+
+// refreshHotScore 根据话题当前的计数和发布时间重新计算热度分值
+func refreshHotScore(db *gorm.DB, topicID string) error {
+	var stats struct {
+		CreatedAt     time.Time
+		PublishedAt   *time.Time
+		UpvoteCount   int64
+		DownvoteCount int64
+		FavoriteCount int64
+		ReplyCount    int64
+	}
+	err := db.Table("topics").
+		Select("created_at, published_at, upvote_count, downvote_count, favorite_count, reply_count").
+		Where("id = ?", topicID).
+		Take(&stats).
+		Error
+	if err != nil {
+		return err
+	}
+	points := HotPoints(stats.UpvoteCount, stats.DownvoteCount, stats.FavoriteCount, stats.ReplyCount)
+	// 定时发布的话题从发布时间开始衰减
+	publishedAt := stats.CreatedAt
+	if stats.PublishedAt != nil {
+		publishedAt = *stats.PublishedAt
+	}
+	return db.Table("topics").
+		Where("id = ?", topicID).
+		UpdateColumn("hot_score", HotScore(points, publishedAt)).
+		Error
+}
@@ -3,8 +3,24 @@ package topic
 import (
 	"gohub/app/models"
 	"gohub/app/models/category"
+	"gohub/app/models/tag"
 	"gohub/app/models/user"
+	"gohub/pkg/config"
 	"gohub/pkg/database"
+	"gohub/pkg/logger"
+	"gohub/pkg/markdown"
+	"gohub/pkg/search"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 话题的发布状态
+const (
+	StatusDraft     = "draft"     // 草稿，只有作者可见
+	StatusPublished = "published" // 已发布
+	StatusScheduled = "scheduled" // 定时发布，到 published_at 时由发布任务发布，见 workers.PublishDueTopics
 )
 
 type Topic struct {
@@ -16,12 +32,44 @@ type Topic struct {
 	UserID     string `json:"user_id,omitempty"`
 	CategoryID string `json:"category_id,omitempty"`
 
+	// 发布状态和发布时间，定时发布的话题 PublishedAt 为计划发布的时间，使用 Publish 发布
+	Status      string     `gorm:"default:published" json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// 置顶的话题在分类的话题列表中排在最前，锁定的话题不能回复
+	Pinned bool `json:"pinned"`
+	Locked bool `json:"locked"`
+
+	// Body 渲染后的 HTML 和纯文本摘要，保存时由 renderBody 生成
+	BodyHTML string `gorm:"column:body_html" json:"body_html,omitempty"`
+	Excerpt  string `json:"excerpt,omitempty"`
+	// 正文中 @ 到的用户 ID，渲染时提取，不保存到数据库
+	MentionedUserIDs []string `gorm:"-" json:"-"`
+
+	// 本次修改的编辑者和修改说明，由控制器在 Save 前设置，BeforeUpdate 记录到修订历史，不保存到数据库
+	// EditorID 为空时记为话题作者
+	EditorID string `gorm:"-" json:"-"`
+	EditNote string `gorm:"-" json:"-"`
+
+	// 回复数和最后回复时间，由 RefreshReplyStats 维护，Save 时不会覆盖
+	ReplyCount  int64      `gorm:"<-:create" json:"reply_count"`
+	LastReplyAt *time.Time `gorm:"<-:create" json:"last_reply_at,omitempty"`
+
+	// 投票数和收藏数，由 RefreshVoteStats 和 RefreshFavoriteStats 维护，Save 时不会覆盖
+	UpvoteCount   int64 `gorm:"<-:create" json:"upvote_count"`
+	DownvoteCount int64 `gorm:"<-:create" json:"downvote_count"`
+	FavoriteCount int64 `gorm:"<-:create" json:"favorite_count"`
+	// 热度分值，用于热度排序，计数变化时重新计算，见 HotScore
+	HotScore float64 `gorm:"<-:create" json:"-"`
+
 	// 通过 user_id 关联用户
 	User user.User `json:"user"`
 	// 通过 category_id 关联分类
 	Category category.Category `json:"category"`
+	// 通过 topic_tags 关联标签，使用 SyncTags 修改
+	Tags []tag.Tag `gorm:"many2many:topic_tags" json:"tags"`
 
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 func (topic *Topic) Create() {
@@ -33,7 +81,148 @@ func (topic *Topic) Save() (rowsAffected int64) {
 	return result.RowsAffected
 }
 
+// Delete 软删除，数据移入回收站
 func (topic *Topic) Delete() (rowsAffected int64) {
 	result := database.DB.Delete(&topic)
 	return result.RowsAffected
 }
+
+// Restore 从回收站恢复
+func (topic *Topic) Restore() (rowsAffected int64) {
+	result := database.DB.Unscoped().Model(&topic).Update("deleted_at", nil)
+	return result.RowsAffected
+}
+
+// ForceDelete 彻底删除，无法恢复
+func (topic *Topic) ForceDelete() (rowsAffected int64) {
+	result := database.DB.Unscoped().Delete(&topic)
+	return result.RowsAffected
+}
+
+// IsPublished 是否已发布，草稿和未到发布时间的定时话题只有作者可见
+func (topic *Topic) IsPublished() bool {
+	return topic.Status == "" || topic.Status == StatusPublished
+}
+
+// Publish 发布草稿或到期的定时话题，已发布的话题返回 false
+// 以状态为条件更新，多个实例同时执行发布任务时只有一个会成功，发布后按发布时间重新计算热度
+func (topic *Topic) Publish() bool {
+	publishedAt := time.Now()
+	if topic.Status == StatusScheduled && topic.PublishedAt != nil && topic.PublishedAt.Before(publishedAt) {
+		publishedAt = *topic.PublishedAt
+	}
+	result := database.DB.Model(&Topic{}).
+		Where("id = ? AND status <> ?", topic.ID, StatusPublished).
+		UpdateColumns(map[string]interface{}{"status": StatusPublished, "published_at": publishedAt})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false
+	}
+
+	// 重新保存以触发 AfterSave，更新搜索索引和标签的话题数
+	topic.Status = StatusPublished
+	topic.PublishedAt = &publishedAt
+	topic.Save()
+	logger.LogIf(refreshHotScore(database.DB, topic.GetStringID()))
+	return true
+}
+
+// SetPinned 置顶或取消置顶
+func (topic *Topic) SetPinned(pinned bool) (rowsAffected int64) {
+	// 状态未变化时不更新，MySQL 对未修改的行返回的影响行数为 0
+	if topic.Pinned == pinned {
+		return 1
+	}
+	result := database.DB.Model(&topic).UpdateColumn("pinned", pinned)
+	return result.RowsAffected
+}
+
+// SetLocked 锁定或解除锁定
+func (topic *Topic) SetLocked(locked bool) (rowsAffected int64) {
+	// 状态未变化时不更新，原因同 SetPinned
+	if topic.Locked == locked {
+		return 1
+	}
+	result := database.DB.Model(&topic).UpdateColumn("locked", locked)
+	return result.RowsAffected
+}
+
+// SyncTags 将话题的标签设置为 tags，移除不在 tags 中的标签，并重新统计相关标签的话题数
+func (topic *Topic) SyncTags(tags []tag.Tag) error {
+	tagIDs := topic.tagIDs(database.DB)
+	if err := database.DB.Model(topic).Omit("Tags.*").Association("Tags").Replace(tags); err != nil {
+		return err
+	}
+	for _, t := range tags {
+		tagIDs = append(tagIDs, t.GetStringID())
+	}
+	topic.Tags = tags
+	return tag.RefreshTopicCount(database.DB, tagIDs...)
+}
+
+// SearchDocument 写入全文搜索索引的内容
+func (topic *Topic) SearchDocument() search.Document {
+	return search.Document{
+		Type:       search.TypeTopic,
+		ID:         topic.GetStringID(),
+		Title:      topic.Title,
+		Body:       topic.Body,
+		CategoryID: topic.CategoryID,
+		UserID:     topic.UserID,
+		CreatedAt:  topic.CreatedAt,
+	}
+}
+
+// renderBody 将 Markdown 正文渲染为 HTML 并生成摘要，@用户名 只有存在时、#话题ID 只有已发布时才生成链接，不暴露草稿和定时发布的话题
+func (topic *Topic) renderBody(tx *gorm.DB) {
+	db := tx.Session(&gorm.Session{NewDB: true})
+	mentioned := make(map[string]string)
+
+	result := markdown.Render(topic.Body, markdown.Options{
+		MentionURL: func(name string) (string, bool) {
+			id, ok := mentioned[name]
+			if !ok {
+				var userModel user.User
+				db.Select("id").Where("name = ?", name).First(&userModel)
+				id = userModel.GetStringID()
+				mentioned[name] = id
+			}
+			return "/users/" + id, id != "0"
+		},
+		TopicURL: func(id string) (string, bool) {
+			var count int64
+			db.Model(&Topic{}).Where("id = ? AND status = ?", id, StatusPublished).Count(&count)
+			return "/topics/" + id, count > 0
+		},
+	})
+
+	topic.BodyHTML = result.HTML
+	topic.Excerpt = markdown.Excerpt(result.HTML, config.GetInt("topic.excerpt_length"))
+	topic.MentionedUserIDs = nil
+	for _, name := range result.Mentions {
+		topic.MentionedUserIDs = append(topic.MentionedUserIDs, mentioned[name])
+	}
+}
+
+// HotScore 热度分值，参考 Reddit 的热度算法：得分取对数，再加上发布时间（秒）除以衰减周期，
+// 即越晚发布的话题基础分越高，相当于旧话题的热度随时间衰减，分值只在计数变化时计算，无需定时更新
+func HotScore(points float64, createdAt time.Time) float64 {
+	sign := 0.0
+	if points > 0 {
+		sign = 1
+	} else if points < 0 {
+		sign = -1
+	}
+	order := math.Log10(math.Max(math.Abs(points), 1))
+	decay := config.GetFloat64("topic.hot.decay")
+	if decay <= 0 {
+		decay = 45000
+	}
+	return sign*order + float64(createdAt.Unix())/decay
+}
+
+// HotPoints 热度得分，由投票、收藏和回复数按 topic.hot 配置的权重计算
+func HotPoints(upvotes, downvotes, favorites, replies int64) float64 {
+	return float64(upvotes-downvotes)*config.GetFloat64("topic.hot.vote_weight") +
+		float64(favorites)*config.GetFloat64("topic.hot.favorite_weight") +
+		float64(replies)*config.GetFloat64("topic.hot.reply_weight")
+}
@@ -1,11 +1,15 @@
 package topic
 
 import (
+	"gohub/app/models/tag"
 	"gohub/pkg/app"
 	"gohub/pkg/database"
 	"gohub/pkg/paginator"
+	"net/url"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -14,6 +18,21 @@ func Get(idstr string) (topic Topic) {
 	return
 }
 
+// GetByIDs 通过 ID 批量获取，不保证顺序
+func GetByIDs(ids []string) (topics []Topic) {
+	if len(ids) == 0 {
+		return
+	}
+	database.DB.Preload(clause.Associations).Where("id IN ?", ids).Find(&topics)
+	return
+}
+
+// GetPublished 通过 ID 获取已发布的话题，回复、投票等操作只针对已发布的话题
+func GetPublished(idstr string) (topic Topic) {
+	database.DB.Preload(clause.Associations).Where("id = ? AND status = ?", idstr, StatusPublished).First(&topic)
+	return
+}
+
 func GetBy(field, value string) (topic Topic) {
 	database.DB.Where("? = ?", field, value).First(&topic)
 	return
@@ -30,13 +49,178 @@ func IsExist(field, value string) bool {
 	return count > 0
 }
 
+// HasInCategory 分类下是否有话题，包含回收站中的话题
+func HasInCategory(categoryID string) bool {
+	var count int64
+	database.DB.Unscoped().Model(Topic{}).Where("category_id = ?", categoryID).Count(&count)
+	return count > 0
+}
+
+// HasUntrashedInCategory 分类下是否有未删除的话题，包括草稿和定时发布的话题
+func HasUntrashedInCategory(categoryID string) bool {
+	var count int64
+	database.DB.Model(Topic{}).Where("category_id = ?", categoryID).Count(&count)
+	return count > 0
+}
+
+// HasByUser 用户是否发布过话题，包含回收站中的话题
+func HasByUser(userID string) bool {
+	var count int64
+	database.DB.Unscoped().Model(Topic{}).Where("user_id = ?", userID).Count(&count)
+	return count > 0
+}
+
+// Filters 话题列表允许的过滤条件，参数格式在 requests.TopicFilter 中验证，标签过滤见 applyFilters
+var Filters = paginator.Filters{
+	{Param: "category_id"},
+	{Param: "user_id"},
+	{Param: "created_after", Column: "created_at", Operator: ">=", Date: true},
+	{Param: "created_before", Column: "created_at", Operator: "<", Date: true},
+}
+
+// SortFields 话题列表允许的排序字段，按热度排序使用 sort=-hot_score
+var SortFields = []string{
+	"id", "created_at", "updated_at", "reply_count", "last_reply_at",
+	"upvote_count", "favorite_count", "hot_score",
+}
+
+// CursorSortFields 游标分页允许的排序字段，排序字段不能有 NULL 值，因此不包含 last_reply_at
+var CursorSortFields = []string{
+	"id", "created_at", "updated_at", "reply_count",
+	"upvote_count", "favorite_count", "hot_score",
+}
+
+// Summaries 列表中只返回摘要，清空正文以减小响应体积
+func Summaries(topics []Topic) []Topic {
+	for i := range topics {
+		topics[i].Body = ""
+		topics[i].BodyHTML = ""
+	}
+	return topics
+}
+
+// Paginate 话题分页，支持 Filters 中的过滤条件
 func Paginate(c *gin.Context, perPage int) (topics []Topic, paging paginator.Page) {
+	return paginate(c, database.DB.Model(Topic{}), database.TableName(&Topic{}), perPage)
+}
+
+// PaginateByCategory 分类下的话题分页，置顶的话题排在最前
+func PaginateByCategory(c *gin.Context, categoryID string, perPage int) (topics []Topic, paging paginator.Page) {
+	query := database.DB.Model(Topic{}).Where("category_id = ?", categoryID).Order("pinned desc")
+	return paginate(c, query, "categories/"+categoryID+"/topics", perPage)
+}
+
+// PaginateByUser 用户发布的话题分页
+func PaginateByUser(c *gin.Context, userID string, perPage int) (topics []Topic, paging paginator.Page) {
+	query := database.DB.Model(Topic{}).Where("user_id = ?", userID)
+	return paginate(c, query, "users/"+userID+"/topics", perPage)
+}
+
+// PaginateByTag 标签下的话题分页
+func PaginateByTag(c *gin.Context, slug string, perPage int) (topics []Topic, paging paginator.Page) {
+	return paginate(c, whereHasTag(database.DB.Model(Topic{}), slug), "tags/"+url.PathEscape(slug)+"/topics", perPage)
+}
+
+// PaginateDrafts 用户的草稿和定时发布的话题分页
+func PaginateDrafts(c *gin.Context, userID string, perPage int) (topics []Topic, paging paginator.Page) {
 	paging = paginator.Paginate(
 		c,
-		database.DB.Model(Topic{}),
+		database.DB.Model(Topic{}).Where("user_id = ? AND status IN ?", userID, []string{StatusDraft, StatusScheduled}),
 		&topics,
-		app.V1URL(database.TableName(&Topic{})),
+		app.V1URL("user/drafts"),
 		perPage,
 	)
 	return
 }
+
+// DueScheduled 已到发布时间的定时话题，最多 limit 条
+func DueScheduled(limit int) (topics []Topic) {
+	database.DB.Preload(clause.Associations).
+		Where("status = ? AND published_at <= ?", StatusScheduled, time.Now()).
+		Order("published_at").
+		Limit(limit).
+		Find(&topics)
+	return
+}
+
+// paginate 已发布话题的分页，草稿和定时发布的话题见 PaginateDrafts
+func paginate(c *gin.Context, query *gorm.DB, path string, perPage int) (topics []Topic, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		applyFilters(c, query.Where("status = ?", StatusPublished)),
+		&topics,
+		app.V1URL(path),
+		perPage,
+	)
+	return
+}
+
+// GetWithTrashed 通过 ID 获取，包含回收站中的数据
+func GetWithTrashed(idstr string) (topic Topic) {
+	database.DB.Unscoped().Preload(clause.Associations).Where("id = ?", idstr).First(&topic)
+	return
+}
+
+// GetTrashed 通过 ID 获取回收站中的数据
+func GetTrashed(idstr string) (topic Topic) {
+	database.DB.Unscoped().Preload(clause.Associations).Where("id = ?", idstr).Where("deleted_at IS NOT NULL").First(&topic)
+	return
+}
+
+// PaginateTrashed 回收站分页内容
+func PaginateTrashed(c *gin.Context, perPage int) (topics []Topic, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Unscoped().Model(Topic{}).Where("deleted_at IS NOT NULL"),
+		&topics,
+		app.V1URL("trash/"+database.TableName(&Topic{})),
+		perPage,
+	)
+	return
+}
+
+// CursorPaginate 话题游标分页，数据量大时使用，支持 Filters 中的过滤条件
+func CursorPaginate(c *gin.Context, perPage int) ([]Topic, paginator.CursorPage, error) {
+	return cursorPaginate(c, database.DB.Model(Topic{}), perPage)
+}
+
+// CursorPaginateByCategory 分类下的话题游标分页，与 PaginateByCategory 一样置顶的话题排在最前
+func CursorPaginateByCategory(c *gin.Context, categoryID string, perPage int) ([]Topic, paginator.CursorPage, error) {
+	return cursorPaginate(c, database.DB.Model(Topic{}).Where("category_id = ?", categoryID), perPage, "pinned")
+}
+
+// CursorPaginateByTag 标签下的话题游标分页
+func CursorPaginateByTag(c *gin.Context, slug string, perPage int) ([]Topic, paginator.CursorPage, error) {
+	return cursorPaginate(c, whereHasTag(database.DB.Model(Topic{}), slug), perPage)
+}
+
+// CursorPaginateByUser 用户发布的话题游标分页
+func CursorPaginateByUser(c *gin.Context, userID string, perPage int) ([]Topic, paginator.CursorPage, error) {
+	return cursorPaginate(c, database.DB.Model(Topic{}).Where("user_id = ?", userID), perPage)
+}
+
+func cursorPaginate(c *gin.Context, query *gorm.DB, perPage int, leading ...string) (topics []Topic, paging paginator.CursorPage, err error) {
+	topics = []Topic{}
+	paging, err = paginator.CursorPaginate(c, applyFilters(c, query.Where("status = ?", StatusPublished)), &topics, perPage, leading...)
+	return
+}
+
+// applyFilters 应用 Filters 中的过滤条件，以及 tag 参数的标签过滤，如 ?tag=go
+func applyFilters(c *gin.Context, query *gorm.DB) *gorm.DB {
+	query = Filters.Apply(c, query)
+	if name := c.Query("tag"); len(name) > 0 {
+		query = whereHasTag(query, tag.Slug(tag.Normalize(name)))
+	}
+	return query
+}
+
+// whereHasTag 只查询有 slug 标签的话题
+func whereHasTag(query *gorm.DB, slug string) *gorm.DB {
+	return query.Where(
+		"id IN (?)",
+		database.DB.Table("topic_tags").
+			Select("topic_tags.topic_id").
+			Joins("JOIN tags ON tags.id = topic_tags.tag_id").
+			Where("tags.slug = ?", slug),
+	)
+}
@@ -0,0 +1,11 @@
+package topic_revision
+
+// func (topicRevision *TopicRevision) BeforeSave(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) AfterCreate(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) AfterSave(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) AfterDelete(tx *gorm.DB) (err error) {}
+// func (topicRevision *TopicRevision) AfterFind(tx *gorm.DB) (err error) {}
@@ -0,0 +1,60 @@
+package topic_revision
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/user"
+	"gohub/pkg/diff"
+)
+
+// TopicRevision 话题的一个版本，由 topic 的 BeforeUpdate 钩子在标题或正文变化时记录，只增不改
+// 话题第一次修改时会同时记录修改前的内容作为第 1 版，之后每次修改记录修改后的内容
+type TopicRevision struct {
+	models.BaseModel
+
+	TopicID string `json:"topic_id,omitempty"`
+	// 版本号，同一话题从 1 开始递增
+	Version int64 `json:"version"`
+	// 该版本的编辑者，第 1 版为话题作者
+	UserID string `json:"user_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body,omitempty"`
+	// 修改说明，如回滚时记录回滚到的版本
+	Note string `json:"note,omitempty"`
+
+	// 通过 user_id 关联编辑者
+	User user.User `json:"user"`
+
+	models.CommonTimestampsField
+}
+
+// Diff 两个版本之间标题和正文的逐行对比
+type Diff struct {
+	From       int64       `json:"from"`
+	To         int64       `json:"to"`
+	Title      []diff.Line `json:"title"`
+	Body       []diff.Line `json:"body"`
+	Insertions int         `json:"insertions"`
+	Deletions  int         `json:"deletions"`
+}
+
+// WithDiff 版本及其与上一个版本的对比
+type WithDiff struct {
+	TopicRevision
+	Diff Diff `json:"diff"`
+}
+
+// Compare 对比由 from 变为当前版本的变化，from 为空（当前为第 1 版）时所有行都是新增
+func (topicRevision *TopicRevision) Compare(from TopicRevision) Diff {
+	d := Diff{
+		From:  from.Version,
+		To:    topicRevision.Version,
+		Title: diff.Lines(from.Title, topicRevision.Title),
+		Body:  diff.Lines(from.Body, topicRevision.Body),
+	}
+	for _, lines := range [][]diff.Line{d.Title, d.Body} {
+		insertions, deletions := diff.Stats(lines)
+		d.Insertions += insertions
+		d.Deletions += deletions
+	}
+	return d
+}
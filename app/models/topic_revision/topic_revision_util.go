@@ -0,0 +1,90 @@
+package topic_revision
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetByVersion 通过版本号获取话题的某个版本
+func GetByVersion(topicID string, version interface{}) (topicRevision TopicRevision) {
+	database.DB.Preload("User").Where("topic_id = ? AND version = ?", topicID, version).First(&topicRevision)
+	return
+}
+
+// GetByVersions 批量获取话题的多个版本，key 为版本号
+func GetByVersions(topicID string, versions []int64) map[int64]TopicRevision {
+	result := make(map[int64]TopicRevision, len(versions))
+	if len(versions) == 0 {
+		return result
+	}
+	var topicRevisions []TopicRevision
+	database.DB.Where("topic_id = ? AND version IN ?", topicID, versions).Find(&topicRevisions)
+	for _, topicRevision := range topicRevisions {
+		result[topicRevision.Version] = topicRevision
+	}
+	return result
+}
+
+// Paginate 话题的修订历史分页，默认按版本从旧到新排列
+func Paginate(c *gin.Context, topicID string, perPage int) (topicRevisions []TopicRevision, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(TopicRevision{}).Where("topic_id = ?", topicID),
+		&topicRevisions,
+		app.V1URL("topics/"+topicID+"/revisions"),
+		perPage,
+	)
+	return
+}
+
+// WithDiffs 为列表中的每个版本附上与上一个版本的对比，对比中已包含正文，列表中不再返回完整正文
+func WithDiffs(topicID string, topicRevisions []TopicRevision) []WithDiff {
+	versions := make([]int64, 0, len(topicRevisions))
+	for _, topicRevision := range topicRevisions {
+		versions = append(versions, topicRevision.Version-1)
+	}
+	previous := GetByVersions(topicID, versions)
+
+	result := make([]WithDiff, 0, len(topicRevisions))
+	for _, topicRevision := range topicRevisions {
+		d := topicRevision.Compare(previous[topicRevision.Version-1])
+		topicRevision.Body = ""
+		result = append(result, WithDiff{TopicRevision: topicRevision, Diff: d})
+	}
+	return result
+}
+
+// Record 记录话题的一个新版本，版本号自动递增，由 topic 的 BeforeUpdate 钩子调用
+// 话题还没有修订历史时，先将修改前的内容 original 记录为第 1 版
+func Record(tx *gorm.DB, original, revision TopicRevision) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var versions []int64
+	err := db.Model(&TopicRevision{}).
+		Where("topic_id = ?", revision.TopicID).
+		Order("version desc").
+		Limit(1).
+		Pluck("version", &versions).
+		Error
+	if err != nil {
+		return err
+	}
+
+	version := int64(0)
+	if len(versions) > 0 {
+		version = versions[0]
+	} else {
+		original.Version = 1
+		if err := db.Create(&original).Error; err != nil {
+			return err
+		}
+		version = original.Version
+	}
+
+	revision.Version = version + 1
+	return db.Create(&revision).Error
+}
@@ -1,15 +1,102 @@
 package user
 
 import (
+	"gohub/pkg/filter"
 	"gohub/pkg/hash"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
 
 	"gorm.io/gorm"
 )
 
+// ForceDeleteFunc 彻底删除用户时清理其他模型中属于该用户的数据，与删除用户在同一事务中执行
+type ForceDeleteFunc func(tx *gorm.DB, userID string) error
+
+// forceDeleteFuncs 由 OnForceDelete 注册的清理方法
+var forceDeleteFuncs []ForceDeleteFunc
+
+// OnForceDelete 注册彻底删除用户时的清理方法，一般在依赖 user 的模型包（如 reply、vote）的 init 方法中调用，
+// 避免 user 包引用这些模型包造成循环引用
+func OnForceDelete(fn ForceDeleteFunc) {
+	forceDeleteFuncs = append(forceDeleteFuncs, fn)
+}
+
 // BeforeSave GORM 的模型钩子，在创建和更新模型前调用
+// 加密密码，并替换个人简介和城市中 mask 模式的敏感词
 func (userModel *User) BeforeSave(tx *gorm.DB) (err error) {
 	if !hash.BcryptIsHashed(userModel.Password) {
 		userModel.Password = hash.BcryptHash(userModel.Password)
 	}
+	userModel.Introduction = filter.NewFilter().Mask(userModel.Introduction)
+	userModel.City = filter.NewFilter().Mask(userModel.City)
 	return err
 }
+
+// AfterSave 更新全文搜索索引，索引失败只记录日志
+func (userModel *User) AfterSave(tx *gorm.DB) (err error) {
+	if userModel.Trashed() {
+		return nil
+	}
+	logger.LogIf(search.NewSearch().Index(tx, userModel.SearchDocument()))
+	return nil
+}
+
+// AfterDelete 从全文搜索索引中删除
+func (userModel *User) AfterDelete(tx *gorm.DB) (err error) {
+	logger.LogIf(search.NewSearch().Delete(tx, search.TypeUser, userModel.GetStringID()))
+	return nil
+}
+
+// RefreshFollowStats 重新统计用户的粉丝数和关注数，由 follow 的 AfterCreate 和 AfterDelete 钩子调用
+func RefreshFollowStats(tx *gorm.DB, userIDs ...string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	for _, userID := range userIDs {
+		var followers, followings int64
+		if err := db.Table("follows").Where("following_id = ?", userID).Count(&followers).Error; err != nil {
+			return err
+		}
+		if err := db.Table("follows").Where("follower_id = ?", userID).Count(&followings).Error; err != nil {
+			return err
+		}
+		err := db.Table("users").Where("id = ?", userID).UpdateColumns(map[string]interface{}{
+			"followers_count":  followers,
+			"followings_count": followings,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFollows 彻底删除用户时删除其关注关系，并更新相关用户的粉丝数和关注数
+// 粉丝可能很多，不逐个重新统计，直接在原计数上减 1
+func deleteFollows(tx *gorm.DB, userID uint64) error {
+	err := tx.Exec("UPDATE users SET followings_count = followings_count - 1 "+
+		"WHERE id IN (SELECT follower_id FROM follows WHERE following_id = ?)", userID).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec("UPDATE users SET followers_count = followers_count - 1 "+
+		"WHERE id IN (SELECT following_id FROM follows WHERE follower_id = ?)", userID).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec("DELETE FROM follows WHERE follower_id = ? OR following_id = ?", userID, userID).Error
+}
+
+// deleteMessages 彻底删除用户时删除其私信、双方的会话和黑名单
+func deleteMessages(tx *gorm.DB, userID uint64) error {
+	conditions := map[string]string{
+		"messages":      "sender_id = ? OR recipient_id = ?",
+		"conversations": "user_id = ? OR peer_id = ?",
+		"blocks":        "user_id = ? OR blocked_id = ?",
+	}
+	for table, condition := range conditions {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE "+condition, userID, userID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,12 @@ import (
 	"gohub/app/models"
 	"gohub/pkg/database"
 	"gohub/pkg/hash"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -19,7 +25,21 @@ type User struct {
 	Phone    string `json:"-"`
 	Password string `json:"-"`
 
+	// 通过验证码或验证链接确认过的时间，为空表示未验证
+	EmailVerifiedAt *time.Time `json:"-"`
+	PhoneVerifiedAt *time.Time `json:"-"`
+
+	// 两步验证，TwoFactorConfirmedAt 不为空时才算开启
+	TwoFactorSecret        string     `json:"-"`
+	TwoFactorRecoveryCodes string     `json:"-"`
+	TwoFactorConfirmedAt   *time.Time `json:"-"`
+
+	// 粉丝数和关注数，由 RefreshFollowStats 维护，Save 时不会覆盖
+	FollowersCount  int64 `gorm:"<-:create" json:"followers_count"`
+	FollowingsCount int64 `gorm:"<-:create" json:"followings_count"`
+
 	models.CommonTimestampsField
+	models.SoftDeletes
 }
 
 // Create 创建用户，通过 User.ID 来判断是否创建成功
@@ -37,3 +57,73 @@ func (userModel *User) Save() (rowsAffected int64) {
 	result := database.DB.Save(&userModel)
 	return result.RowsAffected
 }
+
+// Delete 软删除用户，用户将无法登录，已签发的令牌也随之失效
+func (userModel *User) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&userModel)
+	return result.RowsAffected
+}
+
+// Restore 从回收站恢复用户
+func (userModel *User) Restore() (rowsAffected int64) {
+	result := database.DB.Unscoped().Model(&userModel).Update("deleted_at", nil)
+	return result.RowsAffected
+}
+
+// ForceDelete 彻底删除用户，同时删除第三方账号绑定、个人访问令牌、角色分配、关注关系和私信，
+// 其他模型中属于该用户的数据由 OnForceDelete 注册的方法在同一事务中清理
+func (userModel *User) ForceDelete() (rowsAffected int64) {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"user_identities", "personal_access_tokens", "user_roles"} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", userModel.ID).Error; err != nil {
+				return err
+			}
+		}
+		if err := deleteFollows(tx, userModel.ID); err != nil {
+			return err
+		}
+		if err := deleteMessages(tx, userModel.ID); err != nil {
+			return err
+		}
+		for _, fn := range forceDeleteFuncs {
+			if err := fn(tx, userModel.GetStringID()); err != nil {
+				return err
+			}
+		}
+		result := tx.Unscoped().Delete(&userModel)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		logger.LogIf(err)
+		return 0
+	}
+	return
+}
+
+// HasTwoFactorEnabled 是否已开启两步验证
+func (userModel *User) HasTwoFactorEnabled() bool {
+	return userModel.TwoFactorConfirmedAt != nil && len(userModel.TwoFactorSecret) > 0
+}
+
+// HasVerifiedEmail Email 是否已验证
+func (userModel *User) HasVerifiedEmail() bool {
+	return len(userModel.Email) > 0 && userModel.EmailVerifiedAt != nil
+}
+
+// HasVerifiedPhone 手机号是否已验证
+func (userModel *User) HasVerifiedPhone() bool {
+	return len(userModel.Phone) > 0 && userModel.PhoneVerifiedAt != nil
+}
+
+// SearchDocument 写入全文搜索索引的内容，只包含公开的资料
+func (userModel *User) SearchDocument() search.Document {
+	return search.Document{
+		Type:      search.TypeUser,
+		ID:        userModel.GetStringID(),
+		Title:     userModel.Name,
+		Body:      strings.TrimSpace(userModel.Introduction + " " + userModel.City),
+		UserID:    userModel.GetStringID(),
+		CreatedAt: userModel.CreatedAt,
+	}
+}
@@ -8,17 +8,24 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// IsEmailExist 判断 Email 已被注册
+// IsEmailExist 判断 Email 已被注册，回收站中的用户依然占用，以便恢复
 func IsEmailExist(email string) bool {
 	var count int64
-	database.DB.Model(User{}).Where("email = ?", email).Count(&count)
+	database.DB.Unscoped().Model(User{}).Where("email = ?", email).Count(&count)
 	return count > 0
 }
 
 // IsPhoneExist 判断手机号已被注册
 func IsPhoneExist(phone string) bool {
 	var count int64
-	database.DB.Model(User{}).Where("phone = ?", phone).Count(&count)
+	database.DB.Unscoped().Model(User{}).Where("phone = ?", phone).Count(&count)
+	return count > 0
+}
+
+// IsNameExist 判断用户名已被占用
+func IsNameExist(name string) bool {
+	var count int64
+	database.DB.Unscoped().Model(User{}).Where("name = ?", name).Count(&count)
 	return count > 0
 }
 
@@ -44,6 +51,15 @@ func Get(idstr string) (userModel User) {
 	return
 }
 
+// GetByIDs 通过 ID 批量获取用户，不保证顺序
+func GetByIDs(ids []string) (users []User) {
+	if len(ids) == 0 {
+		return
+	}
+	database.DB.Where("id IN ?", ids).Find(&users)
+	return
+}
+
 // GetByEmail 通过 Email 来获取用户
 func GetByEmail(email string) (userModel User) {
 	database.DB.Where("email = ?", email).First(&userModel)
@@ -67,3 +83,27 @@ func Paginate(c *gin.Context, perPage int) (users []User, page paginator.Page) {
 	)
 	return
 }
+
+// GetWithTrashed 通过 ID 获取，包含回收站中的数据
+func GetWithTrashed(idstr string) (userModel User) {
+	database.DB.Unscoped().Where("id = ?", idstr).First(&userModel)
+	return
+}
+
+// GetTrashed 通过 ID 获取回收站中的数据
+func GetTrashed(idstr string) (userModel User) {
+	database.DB.Unscoped().Where("id = ?", idstr).Where("deleted_at IS NOT NULL").First(&userModel)
+	return
+}
+
+// PaginateTrashed 回收站分页内容
+func PaginateTrashed(c *gin.Context, perPage int) (users []User, paging paginator.Page) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Unscoped().Model(User{}).Where("deleted_at IS NOT NULL"),
+		&users,
+		app.V1URL("trash/"+database.TableName(&User{})),
+		perPage,
+	)
+	return
+}
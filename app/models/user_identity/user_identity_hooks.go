@@ -0,0 +1,11 @@
+package user_identity
+
+// func (userIdentity *UserIdentity) BeforeSave(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) BeforeCreate(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) AfterCreate(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) AfterSave(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) BeforeDelete(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) AfterDelete(tx *gorm.DB) (err error) {}
+// func (userIdentity *UserIdentity) AfterFind(tx *gorm.DB) (err error) {}
\ No newline at end of file
@@ -0,0 +1,34 @@
+package user_identity
+
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+)
+
+// UserIdentity 用户绑定的第三方账号
+type UserIdentity struct {
+	models.BaseModel
+
+	UserID   string `json:"-"`
+	Provider string `json:"provider"`
+	Subject  string `json:"-"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+
+	models.CommonTimestampsField
+}
+
+func (userIdentity *UserIdentity) Create() {
+	database.DB.Create(&userIdentity)
+}
+
+func (userIdentity *UserIdentity) Save() (rowsAffected int64) {
+	result := database.DB.Save(&userIdentity)
+	return result.RowsAffected
+}
+
+func (userIdentity *UserIdentity) Delete() (rowsAffected int64) {
+	result := database.DB.Delete(&userIdentity)
+	return result.RowsAffected
+}
@@ -0,0 +1,28 @@
+package user_identity
+
+import (
+	"gohub/pkg/database"
+)
+
+func Get(idstr string) (userIdentity UserIdentity) {
+	database.DB.Where("id", idstr).First(&userIdentity)
+	return
+}
+
+// GetBySubject 通过第三方平台的用户标识获取
+func GetBySubject(provider, subject string) (userIdentity UserIdentity) {
+	database.DB.Where("provider = ? AND subject = ?", provider, subject).First(&userIdentity)
+	return
+}
+
+// GetByUser 获取用户绑定的某个平台的账号
+func GetByUser(userID, provider string) (userIdentity UserIdentity) {
+	database.DB.Where("user_id = ? AND provider = ?", userID, provider).First(&userIdentity)
+	return
+}
+
+// AllByUser 获取用户绑定的所有第三方账号
+func AllByUser(userID string) (userIdentities []UserIdentity) {
+	database.DB.Where("user_id = ?", userID).Order("id").Find(&userIdentities)
+	return
+}
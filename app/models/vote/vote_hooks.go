@@ -0,0 +1,57 @@
+package vote
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	user.OnForceDelete(deleteByUser)
+}
+
+// func (vote *Vote) BeforeSave(tx *gorm.DB) (err error) {}
+// func (vote *Vote) BeforeCreate(tx *gorm.DB) (err error) {}
+
+// AfterCreate 更新话题的投票数，改票（upsert 更新已有记录）时也会调用
+func (vote *Vote) AfterCreate(tx *gorm.DB) (err error) {
+	return topic.RefreshVoteStats(tx, vote.TopicID)
+}
+
+// func (vote *Vote) BeforeUpdate(tx *gorm.DB) (err error) {}
+// func (vote *Vote) AfterUpdate(tx *gorm.DB) (err error) {}
+// func (vote *Vote) AfterSave(tx *gorm.DB) (err error) {}
+// func (vote *Vote) BeforeDelete(tx *gorm.DB) (err error) {}
+
+// AfterDelete 更新话题的投票数
+func (vote *Vote) AfterDelete(tx *gorm.DB) (err error) {
+	if len(vote.TopicID) == 0 {
+		return nil
+	}
+	return topic.RefreshVoteStats(tx, vote.TopicID)
+}
+
+// func (vote *Vote) AfterFind(tx *gorm.DB) (err error) {}
+
+// deleteByUser 彻底删除用户时删除其所有投票，并重新统计相关话题的投票数
+func deleteByUser(tx *gorm.DB, userID string) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var topicIDs []string
+	if err := db.Model(&Vote{}).Where("user_id = ?", userID).Distinct().Pluck("topic_id", &topicIDs).Error; err != nil {
+		return err
+	}
+	if len(topicIDs) == 0 {
+		return nil
+	}
+	if err := db.Exec("DELETE FROM votes WHERE user_id = ?", userID).Error; err != nil {
+		return err
+	}
+	for _, topicID := range topicIDs {
+		if err := topic.RefreshVoteStats(tx, topicID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package vote
+
+import (
+	"gohub/app/models"
+)
+
+const (
+	Up   = 1  // 赞成
+	Down = -1 // 反对
+)
+
+// Vote 用户对话题的投票，每个用户对每个话题只有一票（user_id + topic_id 唯一）
+type Vote struct {
+	models.BaseModel
+
+	UserID  string `json:"user_id,omitempty"`
+	TopicID string `json:"topic_id,omitempty"`
+	// 1 为赞成，-1 为反对
+	Value int `json:"value"`
+
+	models.CommonTimestampsField
+}
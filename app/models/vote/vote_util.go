@@ -0,0 +1,31 @@
+package vote
+
+import (
+	"gohub/pkg/database"
+
+	"gorm.io/gorm/clause"
+)
+
+// Get 获取用户对话题的投票，未投票时 ID 为 0
+func Get(userID, topicID string) (vote Vote) {
+	database.DB.Where("user_id = ? AND topic_id = ?", userID, topicID).First(&vote)
+	return
+}
+
+// Cast 投票，已投过票时改为新的值，重复投相同的票不会重复计数
+// 使用 upsert 而不是先查询再写入，并发请求时由唯一索引保证每人只有一票
+func Cast(userID, topicID string, value int) error {
+	vote := Vote{UserID: userID, TopicID: topicID, Value: value}
+	return database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "topic_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&vote).Error
+}
+
+// Retract 撤销投票，未投过票时不做任何处理
+func Retract(userID, topicID string) error {
+	return database.DB.
+		Where("user_id = ? AND topic_id = ?", userID, topicID).
+		Delete(&Vote{TopicID: topicID}).
+		Error
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"fmt"
+	"gohub/app/models/message"
+	"gohub/app/models/user"
+	"html"
+)
+
+// MessageReceived 收到私信，通知接收人
+type MessageReceived struct {
+	ConversationID string
+	Message        message.Message
+	Sender         user.User
+}
+
+// MessageReceivedData MessageReceived 站内信的内容，conversation_id 为接收人的会话
+type MessageReceivedData struct {
+	ConversationID string `json:"conversation_id"`
+	MessageID      string `json:"message_id"`
+	MessageExcerpt string `json:"message_excerpt"`
+	UserID         string `json:"user_id"`
+	UserName       string `json:"user_name"`
+}
+
+func (n MessageReceived) Type() string {
+	return "message_received"
+}
+
+func (n MessageReceived) ToDatabase() interface{} {
+	return MessageReceivedData{
+		ConversationID: n.ConversationID,
+		MessageID:      n.Message.GetStringID(),
+		MessageExcerpt: excerpt(n.Message.Body, 100),
+		UserID:         n.Sender.GetStringID(),
+		UserName:       n.Sender.Name,
+	}
+}
+
+func (n MessageReceived) ToMail() (subject, content string) {
+	subject = fmt.Sprintf("%v 给你发送了私信", n.Sender.Name)
+	content = fmt.Sprintf("<h1>%v</h1><blockquote>%v</blockquote>",
+		html.EscapeString(subject), html.EscapeString(excerpt(n.Message.Body, 200)))
+	return
+}
@@ -0,0 +1,53 @@
+// Package notifications 站内通知的类型，每种通知定义各渠道的内容，通过 Send 按用户的偏好发送
+package notifications
+
+import (
+	"gohub/app/models/notification_preference"
+	"gohub/app/models/user"
+	"gohub/pkg/helpers"
+	"gohub/pkg/logger"
+	"gohub/pkg/notify"
+	"gohub/pkg/realtime"
+	"strings"
+	"unicode/utf8"
+)
+
+// Send 按用户设置的渠道发送通知，调用示例：
+//         notifications.Send(topicModel.UserID, notifications.TopicVoted{Topic: topicModel, Voter: voter})
+// 邮件和短信只发送到已验证的 Email 和手机号，发送失败只记录日志
+func Send(userID string, message notify.Message) {
+	userModel := user.Get(userID)
+	if userModel.ID == 0 {
+		return
+	}
+	channels := notification_preference.Channels(userID, message.Type())
+	if len(channels) == 0 {
+		return
+	}
+
+	recipient := notify.Recipient{ID: userModel.GetStringID()}
+	if userModel.HasVerifiedEmail() {
+		recipient.Email = userModel.Email
+	}
+	if userModel.HasVerifiedPhone() {
+		recipient.Phone = userModel.Phone
+	}
+	logger.LogIf(notify.NewNotifier().Send(recipient, message, channels))
+
+	// 在线的用户通过私有频道实时收到站内信
+	if msg, ok := message.(notify.DatabaseMessage); ok && helpers.InSlice(notify.ChannelDatabase, channels) {
+		logger.LogIf(realtime.NewHub().Publish(realtime.UserChannel(userID), "notification.created", map[string]interface{}{
+			"type": msg.Type(),
+			"data": msg.ToDatabase(),
+		}))
+	}
+}
+
+// excerpt 截取前 length 个字符作为摘要
+func excerpt(text string, length int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length]) + "..."
+}
@@ -0,0 +1,51 @@
+package notifications
+
+import (
+	"fmt"
+	"gohub/app/models/reply"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"html"
+)
+
+// ReplyReplied 回复收到回复，通知父回复的作者
+type ReplyReplied struct {
+	Topic   topic.Topic
+	Parent  reply.Reply
+	Reply   reply.Reply
+	Replier user.User
+}
+
+// ReplyRepliedData ReplyReplied 站内信的内容
+type ReplyRepliedData struct {
+	TopicID      string `json:"topic_id"`
+	TopicTitle   string `json:"topic_title"`
+	ParentID     string `json:"parent_id"`
+	ReplyID      string `json:"reply_id"`
+	ReplyExcerpt string `json:"reply_excerpt"`
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+}
+
+func (n ReplyReplied) Type() string {
+	return "reply_replied"
+}
+
+func (n ReplyReplied) ToDatabase() interface{} {
+	return ReplyRepliedData{
+		TopicID:      n.Topic.GetStringID(),
+		TopicTitle:   n.Topic.Title,
+		ParentID:     n.Parent.GetStringID(),
+		ReplyID:      n.Reply.GetStringID(),
+		ReplyExcerpt: excerpt(n.Reply.Body, 100),
+		UserID:       n.Replier.GetStringID(),
+		UserName:     n.Replier.Name,
+	}
+}
+
+func (n ReplyReplied) ToMail() (subject, content string) {
+	subject = fmt.Sprintf("%v 在《%v》中回复了你", n.Replier.Name, n.Topic.Title)
+	content = fmt.Sprintf("<h1>%v</h1><blockquote>%v</blockquote>",
+		html.EscapeString(subject), html.EscapeString(excerpt(n.Reply.Body, 200)))
+	return
+}
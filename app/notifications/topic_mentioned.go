@@ -0,0 +1,43 @@
+package notifications
+
+import (
+	"fmt"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"html"
+)
+
+// TopicMentioned 话题正文中 @ 了用户，通知被 @ 的用户
+type TopicMentioned struct {
+	Topic  topic.Topic
+	Author user.User
+}
+
+// TopicMentionedData TopicMentioned 站内信的内容
+type TopicMentionedData struct {
+	TopicID      string `json:"topic_id"`
+	TopicTitle   string `json:"topic_title"`
+	TopicExcerpt string `json:"topic_excerpt"`
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+}
+
+func (n TopicMentioned) Type() string {
+	return "topic_mentioned"
+}
+
+func (n TopicMentioned) ToDatabase() interface{} {
+	return TopicMentionedData{
+		TopicID:      n.Topic.GetStringID(),
+		TopicTitle:   n.Topic.Title,
+		TopicExcerpt: excerpt(n.Topic.Excerpt, 100),
+		UserID:       n.Author.GetStringID(),
+		UserName:     n.Author.Name,
+	}
+}
+
+func (n TopicMentioned) ToMail() (subject, content string) {
+	subject = fmt.Sprintf("%v 在话题《%v》中提到了你", n.Author.Name, n.Topic.Title)
+	content = fmt.Sprintf("<h1>%v</h1><div>%v</div>", html.EscapeString(subject), n.Topic.BodyHTML)
+	return
+}
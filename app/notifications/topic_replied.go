@@ -0,0 +1,57 @@
+package notifications
+
+import (
+	"fmt"
+	"gohub/app/models/reply"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/config"
+	"gohub/pkg/sms"
+	"html"
+)
+
+// TopicReplied 话题收到回复，通知话题作者
+type TopicReplied struct {
+	Topic   topic.Topic
+	Reply   reply.Reply
+	Replier user.User
+}
+
+// TopicRepliedData TopicReplied 站内信的内容
+type TopicRepliedData struct {
+	TopicID      string `json:"topic_id"`
+	TopicTitle   string `json:"topic_title"`
+	ReplyID      string `json:"reply_id"`
+	ReplyExcerpt string `json:"reply_excerpt"`
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+}
+
+func (n TopicReplied) Type() string {
+	return "topic_replied"
+}
+
+func (n TopicReplied) ToDatabase() interface{} {
+	return TopicRepliedData{
+		TopicID:      n.Topic.GetStringID(),
+		TopicTitle:   n.Topic.Title,
+		ReplyID:      n.Reply.GetStringID(),
+		ReplyExcerpt: excerpt(n.Reply.Body, 100),
+		UserID:       n.Replier.GetStringID(),
+		UserName:     n.Replier.Name,
+	}
+}
+
+func (n TopicReplied) ToMail() (subject, content string) {
+	subject = fmt.Sprintf("%v 回复了你的话题《%v》", n.Replier.Name, n.Topic.Title)
+	content = fmt.Sprintf("<h1>%v</h1><blockquote>%v</blockquote>",
+		html.EscapeString(subject), html.EscapeString(excerpt(n.Reply.Body, 200)))
+	return
+}
+
+func (n TopicReplied) ToSMS() sms.Message {
+	return sms.Message{
+		Template: config.GetString("notify.sms_template_code"),
+		Data:     map[string]string{"name": n.Replier.Name, "title": excerpt(n.Topic.Title, 20)},
+	}
+}
@@ -0,0 +1,41 @@
+package notifications
+
+import (
+	"fmt"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"html"
+)
+
+// TopicVoted 话题收到赞成票，通知话题作者，反对票不通知
+type TopicVoted struct {
+	Topic topic.Topic
+	Voter user.User
+}
+
+// TopicVotedData TopicVoted 站内信的内容
+type TopicVotedData struct {
+	TopicID    string `json:"topic_id"`
+	TopicTitle string `json:"topic_title"`
+	UserID     string `json:"user_id"`
+	UserName   string `json:"user_name"`
+}
+
+func (n TopicVoted) Type() string {
+	return "topic_voted"
+}
+
+func (n TopicVoted) ToDatabase() interface{} {
+	return TopicVotedData{
+		TopicID:    n.Topic.GetStringID(),
+		TopicTitle: n.Topic.Title,
+		UserID:     n.Voter.GetStringID(),
+		UserName:   n.Voter.Name,
+	}
+}
+
+func (n TopicVoted) ToMail() (subject, content string) {
+	subject = fmt.Sprintf("%v 赞了你的话题《%v》", n.Voter.Name, n.Topic.Title)
+	content = fmt.Sprintf("<h1>%v</h1>", html.EscapeString(subject))
+	return
+}
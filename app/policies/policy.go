@@ -0,0 +1,32 @@
+package policies
+
+import (
+	"gohub/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyFunc 判断当前用户能否对 model 执行某个操作，model 为模型对象（非指针）
+type PolicyFunc func(c *gin.Context, model interface{}) bool
+
+// policies 所有已注册的策略，key 为权限名称，如 topic.update
+var policies = make(map[string]PolicyFunc)
+
+// Register 注册策略，一般在各 policy 文件的 init 方法中调用
+func Register(ability string, fn PolicyFunc) {
+	policies[ability] = fn
+}
+
+// Allows 判断当前用户能否对 model 执行 ability 操作，依次检查：
+// 1. 管理员拥有所有权限；
+// 2. 通过角色获得了 ability 权限（如版主可以编辑所有话题）；
+// 3. 交由注册的策略判断（如作者可以编辑自己的话题）。
+func Allows(c *gin.Context, ability string, model interface{}) bool {
+	if auth.Can(c, ability) {
+		return true
+	}
+	if fn, ok := policies[ability]; ok {
+		return fn(c, model)
+	}
+	return false
+}
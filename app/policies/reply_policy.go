@@ -0,0 +1,27 @@
+package policies
+
+import (
+	"gohub/app/models/reply"
+	"gohub/pkg/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register("reply.update", isReplyAuthor)
+	Register("reply.delete", isReplyAuthor)
+}
+
+func CanModifyReply(c *gin.Context, _reply reply.Reply) bool {
+	return Allows(c, "reply.update", _reply)
+}
+
+func CanDeleteReply(c *gin.Context, _reply reply.Reply) bool {
+	return Allows(c, "reply.delete", _reply)
+}
+
+// isReplyAuthor 回复作者可以修改和删除自己的回复
+func isReplyAuthor(c *gin.Context, model interface{}) bool {
+	_reply, ok := model.(reply.Reply)
+	return ok && auth.CurrentUID(c) == _reply.UserID
+}
@@ -7,6 +7,38 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+func init() {
+	Register("topic.update", isTopicAuthor)
+	Register("topic.delete", isTopicAuthor)
+	Register("topic.reply", isTopicOpen)
+}
+
 func CanModifyTopic(c *gin.Context, _topic topic.Topic) bool {
-	return auth.CurrentUID(c) == _topic.UserID
+	return Allows(c, "topic.update", _topic)
+}
+
+func CanDeleteTopic(c *gin.Context, _topic topic.Topic) bool {
+	return Allows(c, "topic.delete", _topic)
+}
+
+// CanViewTopic 草稿和定时发布的话题只有能编辑的用户可见
+func CanViewTopic(c *gin.Context, _topic topic.Topic) bool {
+	return _topic.IsPublished() || CanModifyTopic(c, _topic)
+}
+
+// CanReplyTopic 锁定的话题不能回复，拥有 topic.reply 权限的用户（如管理员）不受限制
+func CanReplyTopic(c *gin.Context, _topic topic.Topic) bool {
+	return Allows(c, "topic.reply", _topic)
+}
+
+// isTopicAuthor 话题作者可以修改和删除自己的话题
+func isTopicAuthor(c *gin.Context, model interface{}) bool {
+	_topic, ok := model.(topic.Topic)
+	return ok && auth.CurrentUID(c) == _topic.UserID
+}
+
+// isTopicOpen 已发布且未锁定的话题所有人都可以回复
+func isTopicOpen(c *gin.Context, model interface{}) bool {
+	_topic, ok := model.(topic.Topic)
+	return ok && _topic.IsPublished() && !_topic.Locked
 }
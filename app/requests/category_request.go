@@ -13,8 +13,8 @@ type CategoryRequest struct {
 func CategorySave(data interface{}, c *gin.Context) map[string][]string {
 
 	rules := govalidator.MapData{
-		"name":        []string{"required", "min_cn:2", "max_cn:8", "not_exists:categories,name"},
-		"description": []string{"min_cn:3", "max_cn:255"},
+		"name":        []string{"required", "min_cn:2", "max_cn:8", "not_exists:categories,name", "no_sensitive_words"},
+		"description": []string{"min_cn:3", "max_cn:255", "no_sensitive_words"},
 	}
 	messages := govalidator.MapData{
 		"name": []string{
@@ -0,0 +1,77 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type ConversationRequest struct {
+	UserID string `json:"user_id,omitempty" valid:"user_id"`
+}
+
+// ConversationStart 开始私信会话，user_id 为对方用户
+func ConversationStart(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"user_id": []string{"required", "exists:users,id"},
+	}
+	messages := govalidator.MapData{
+		"user_id": []string{
+			"required:用户 ID 为必填项",
+			"exists:用户不存在",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type ConversationFilterRequest struct {
+	Unread  string `valid:"unread" form:"unread"`
+	Sort    string `valid:"sort" form:"sort"`
+	Order   string `valid:"order" form:"order"`
+	PerPage string `valid:"per_page" form:"per_page"`
+}
+
+// ConversationFilter 会话列表的分页参数，unread=1 时只返回有未读消息的会话
+func ConversationFilter(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"unread":   []string{"in:0,1,true,false"},
+		"sort":     []string{"sort_fields:id,last_message_at,unread_count"},
+		"order":    []string{"in:asc,desc"},
+		"per_page": []string{"numeric_between:2,100"},
+	}
+	messages := govalidator.MapData{
+		"unread": []string{
+			"in:unread 仅支持 0, 1, true, false",
+		},
+		"sort": []string{
+			"sort_fields:排序字段仅支持 id,last_message_at,unread_count，多个字段以逗号分隔，倒序在字段前加 -",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc(正序), desc(倒序)",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type MessageRequest struct {
+	Body string `json:"body,omitempty" valid:"body"`
+}
+
+// MessageSave 发送私信
+func MessageSave(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"body": []string{"required", "max_cn:2000", "no_sensitive_words"},
+	}
+	messages := govalidator.MapData{
+		"body": []string{
+			"required:私信内容为必填项",
+			"max_cn:私信内容长度需小于 2000",
+		},
+	}
+	return validate(data, rules, messages)
+}
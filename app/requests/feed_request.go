@@ -0,0 +1,29 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type FeedRequest struct {
+	Cursor  string `valid:"cursor" form:"cursor"`
+	PerPage string `valid:"per_page" form:"per_page"`
+}
+
+// Feed 时间线的分页参数，cursor 为上一页返回的 next_cursor
+func Feed(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"cursor":   []string{"numeric"},
+		"per_page": []string{"numeric_between:2,100"},
+	}
+	messages := govalidator.MapData{
+		"cursor": []string{
+			"numeric:游标格式错误",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -10,6 +10,7 @@ import (
 type LoginByPhoneRequest struct {
 	Phone      string `json:"phone,omitempty" valid:"phone"`
 	VerifyCode string `json:"verify_code,omitempty" valid:"verify_code"`
+	DeviceName string `json:"device_name,omitempty" valid:"device_name"`
 }
 
 // LoginByPhone 验证表单，返回长度等于零即通过
@@ -18,6 +19,7 @@ func LoginByPhone(data interface{}, c *gin.Context) map[string][]string {
 	rules := govalidator.MapData{
 		"phone":       []string{"required", "digits:11"},
 		"verify_code": []string{"required", "digits:6"},
+		"device_name": []string{"max_cn:50"},
 	}
 
 	// 自定义错误
@@ -30,6 +32,9 @@ func LoginByPhone(data interface{}, c *gin.Context) map[string][]string {
 			"required:验证码答案必填",
 			"digits:验证码长度必须为 6 位的数字",
 		},
+		"device_name": []string{
+			"max_cn:设备名称长度不能超过 50 个字",
+		},
 	}
 
 	errs := validate(data, rules, messages)
@@ -45,6 +50,7 @@ type LoginByPasswordRequest struct {
 	CaptchaAnswer string `json:"captcha_answer,omitempty" valid:"captcha_answer"`
 	LoginID       string `json:"login_id" valid:"login_id"`
 	Password      string `json:"password,omitempty" valid:"password"`
+	DeviceName    string `json:"device_name,omitempty" valid:"device_name"`
 }
 
 // LoginByPassword 验证表单，返回长度等于零即通过
@@ -52,8 +58,8 @@ func LoginByPassword(data interface{}, c *gin.Context) map[string][]string {
 	rules := govalidator.MapData{
 		"login_id":       []string{"required", "min:3"},
 		"password":       []string{"required", "min:6"},
-		"captcha_id":     []string{"required"},
-		"captcha_answer": []string{"required", "digits:6"},
+		"captcha_answer": []string{"digits:6"},
+		"device_name":    []string{"max_cn:50"},
 	}
 
 	messages := govalidator.MapData{
@@ -65,19 +71,14 @@ func LoginByPassword(data interface{}, c *gin.Context) map[string][]string {
 			"required:密码为必填项",
 			"min:密码长度需大于 6",
 		},
-		"captcha_id": []string{
-			"required:图片验证码的 ID 为必填",
-		},
 		"captcha_answer": []string{
-			"required:图片验证码答案必填",
 			"digits:图片验证码长度必须为 6 位的数字",
 		},
+		"device_name": []string{
+			"max_cn:设备名称长度不能超过 50 个字",
+		},
 	}
 
-	errs := validate(data, rules, messages)
-
-	_data := data.(*LoginByPasswordRequest)
-	errs = validators.ValidateCaptcha(_data.CaptchaID, _data.CaptchaAnswer, errs)
-
-	return errs
+	// 图片验证码只在登录失败次数较多时需要，由控制器根据账号的锁定状态校验
+	return validate(data, rules, messages)
 }
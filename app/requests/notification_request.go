@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"gohub/app/models/notification_preference"
+	"gohub/pkg/helpers"
+	"gohub/pkg/notify"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type NotificationFilterRequest struct {
+	Unread  string `valid:"unread" form:"unread"`
+	Sort    string `valid:"sort" form:"sort"`
+	Order   string `valid:"order" form:"order"`
+	PerPage string `valid:"per_page" form:"per_page"`
+}
+
+// NotificationFilter 通知列表的分页参数，unread=1 时只返回未读通知
+func NotificationFilter(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"unread":   []string{"in:0,1,true,false"},
+		"sort":     []string{"sort_fields:id,created_at,read_at"},
+		"order":    []string{"in:asc,desc"},
+		"per_page": []string{"numeric_between:2,100"},
+	}
+	messages := govalidator.MapData{
+		"unread": []string{
+			"in:unread 仅支持 0, 1, true, false",
+		},
+		"sort": []string{
+			"sort_fields:排序字段仅支持 id,created_at,read_at，多个字段以逗号分隔，倒序在字段前加 -",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc(正序), desc(倒序)",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type NotificationPreferenceRequest struct {
+	Type     string   `json:"type,omitempty" valid:"type"`
+	Channels []string `json:"channels" valid:"channels"`
+}
+
+// NotificationPreferenceSave 设置某类通知的接收渠道，channels 为空数组表示不接收此类通知
+func NotificationPreferenceSave(data interface{}, c *gin.Context) map[string][]string {
+	types := notification_preference.Types()
+
+	rules := govalidator.MapData{
+		"type": []string{"required", "in:" + strings.Join(types, ",")},
+	}
+	messages := govalidator.MapData{
+		"type": []string{
+			"required:通知类型为必填项",
+			"in:通知类型仅支持 " + strings.Join(types, ","),
+		},
+	}
+	errs := validate(data, rules, messages)
+
+	channels := []string{notify.ChannelDatabase, notify.ChannelMail, notify.ChannelSMS}
+	_data := data.(*NotificationPreferenceRequest)
+	for _, channel := range _data.Channels {
+		if !helpers.InSlice(channel, channels) {
+			errs["channels"] = append(errs["channels"], "不支持的通知渠道 "+channel+"，可选值："+strings.Join(channels, ","))
+		}
+	}
+	return errs
+}
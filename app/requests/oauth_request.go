@@ -0,0 +1,45 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type OAuthRedirectRequest struct {
+	DeviceName string `valid:"device_name" form:"device_name"`
+}
+
+// OAuthRedirect 验证表单，返回长度等于零即通过
+func OAuthRedirect(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"device_name": []string{"max_cn:50"},
+	}
+	messages := govalidator.MapData{
+		"device_name": []string{
+			"max_cn:设备名称长度不能超过 50 个字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type OAuthCallbackRequest struct {
+	Code  string `valid:"code" form:"code"`
+	State string `valid:"state" form:"state"`
+}
+
+// OAuthCallback 验证第三方平台回调携带的参数
+func OAuthCallback(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"code":  []string{"required"},
+		"state": []string{"required"},
+	}
+	messages := govalidator.MapData{
+		"code": []string{
+			"required:缺少授权码，可能已取消授权",
+		},
+		"state": []string{
+			"required:缺少 state 参数",
+		},
+	}
+	return validate(data, rules, messages)
+}
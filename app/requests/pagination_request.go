@@ -13,14 +13,14 @@ type PaginationRequest struct {
 
 func Pagination(data interface{}, c *gin.Context) map[string][]string {
 	rules := govalidator.MapData{
-		"sort":     []string{"in:id,created_at,updated_at"},
+		"sort":     []string{"sort_fields:id,created_at,updated_at"},
 		"order":    []string{"in:asc,desc"},
 		"per_page": []string{"numeric_between:2,100"},
 	}
 
 	messages := govalidator.MapData{
 		"sort": []string{
-			"in:排序字段仅支持 id,created_at,updated_at",
+			"sort_fields:排序字段仅支持 id,created_at,updated_at，多个字段以逗号分隔，倒序在字段前加 -",
 		},
 		"order": []string{
 			"in:排序规则仅支持 asc(正序), desc(倒序)",
@@ -0,0 +1,56 @@
+package requests
+
+import (
+	"fmt"
+	"gohub/pkg/config"
+	"gohub/pkg/helpers"
+	"gohub/pkg/limiter"
+	"gohub/pkg/pat"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type PersonalAccessTokenRequest struct {
+	Name      string   `json:"name,omitempty" valid:"name"`
+	Scopes    []string `json:"scopes,omitempty" valid:"scopes"`
+	ExpiresIn int      `json:"expires_in,omitempty" valid:"expires_in"`
+	RateLimit string   `json:"rate_limit,omitempty" valid:"rate_limit"`
+}
+
+// PersonalAccessTokenSave 验证表单，返回长度等于零即通过
+func PersonalAccessTokenSave(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"name":   []string{"required", "min_cn:2", "max_cn:50"},
+		"scopes": []string{"required"},
+	}
+	messages := govalidator.MapData{
+		"name": []string{
+			"required:令牌名称为必填项",
+			"min_cn:令牌名称长度需至少 2 个字",
+			"max_cn:令牌名称长度不能超过 50 个字",
+		},
+		"scopes": []string{
+			"required:作用域为必填项，可选值：" + strings.Join(pat.ScopeNames(), ","),
+		},
+	}
+	errs := validate(data, rules, messages)
+
+	_data := data.(*PersonalAccessTokenRequest)
+	for _, scope := range _data.Scopes {
+		if !helpers.InSlice(scope, pat.ScopeNames()) {
+			errs["scopes"] = append(errs["scopes"], "不支持的作用域 "+scope)
+		}
+	}
+	if len(_data.RateLimit) > 0 {
+		if _, err := limiter.ParseRate(_data.RateLimit); err != nil {
+			errs["rate_limit"] = append(errs["rate_limit"], "限流规则格式错误，示例：60-M、1000-H")
+		}
+	}
+	// 有效期的单位为天，不传时永不过期
+	if maxDays := config.GetInt("pat.max_expire_days"); _data.ExpiresIn < 0 || _data.ExpiresIn > maxDays {
+		errs["expires_in"] = append(errs["expires_in"], fmt.Sprintf("有效期的单位为天，介于 1~%d 之间，不传时永不过期", maxDays))
+	}
+	return errs
+}
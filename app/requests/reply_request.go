@@ -0,0 +1,33 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type ReplyRequest struct {
+	Body     string `json:"body,omitempty" valid:"body"`
+	ParentID string `json:"parent_id,omitempty" valid:"parent_id"`
+}
+
+func ReplySave(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"body":      []string{"required", "min_cn:2", "max_cn:10000", "no_sensitive_words"},
+		"parent_id": []string{"numeric"},
+	}
+	messages := govalidator.MapData{
+		"body": []string{
+			"required:回复内容为必填项",
+			"min_cn:回复内容长度需大于 2",
+			"max_cn:回复内容长度需小于 10000",
+		},
+		"parent_id": []string{
+			"numeric:父回复 ID 格式错误",
+		},
+	}
+	errs := validate(data, rules, messages)
+
+	_data := data.(*ReplyRequest)
+	return checkSpam(c, errs, "body", _data.Body)
+}
@@ -0,0 +1,150 @@
+package requests
+
+import (
+	"gohub/app/models/moderation_log"
+	"gohub/app/models/report"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type ReportRequest struct {
+	Reason      string `json:"reason,omitempty" valid:"reason"`
+	Description string `json:"description,omitempty" valid:"description"`
+}
+
+// ReportSave 举报话题，原因为 other 时必须填写说明
+func ReportSave(data interface{}, c *gin.Context) map[string][]string {
+	reasons := strings.Join(report.Reasons, ",")
+
+	rules := govalidator.MapData{
+		"reason":      []string{"required", "in:" + reasons},
+		"description": []string{"max_cn:500"},
+	}
+	messages := govalidator.MapData{
+		"reason": []string{
+			"required:举报原因为必填项",
+			"in:举报原因仅支持 " + reasons,
+		},
+		"description": []string{
+			"max_cn:说明长度需小于 500",
+		},
+	}
+	errs := validate(data, rules, messages)
+
+	_data := data.(*ReportRequest)
+	if _data.Reason == report.ReasonOther && len(strings.TrimSpace(_data.Description)) == 0 {
+		errs["description"] = append(errs["description"], "请填写举报说明")
+	}
+	return errs
+}
+
+type ReportFilterRequest struct {
+	Status  string `valid:"status" form:"status"`
+	Reason  string `valid:"reason" form:"reason"`
+	TopicID string `valid:"topic_id" form:"topic_id"`
+	Sort    string `valid:"sort" form:"sort"`
+	Order   string `valid:"order" form:"order"`
+	PerPage string `valid:"per_page" form:"per_page"`
+}
+
+// ReportFilter 举报列表的过滤和排序参数，待处理队列即 ?status=pending
+func ReportFilter(data interface{}, c *gin.Context) map[string][]string {
+	statuses := strings.Join([]string{report.StatusPending, report.StatusResolved, report.StatusDismissed}, ",")
+	reasons := strings.Join(report.Reasons, ",")
+
+	rules := govalidator.MapData{
+		"status":   []string{"in:" + statuses},
+		"reason":   []string{"in:" + reasons},
+		"topic_id": []string{"numeric"},
+		"sort":     []string{"sort_fields:id,created_at,handled_at"},
+		"order":    []string{"in:asc,desc"},
+		"per_page": []string{"numeric_between:2,100"},
+	}
+	messages := govalidator.MapData{
+		"status": []string{
+			"in:状态仅支持 " + statuses,
+		},
+		"reason": []string{
+			"in:举报原因仅支持 " + reasons,
+		},
+		"topic_id": []string{
+			"numeric:话题 ID 格式错误",
+		},
+		"sort": []string{
+			"sort_fields:排序字段仅支持 id,created_at,handled_at，多个字段以逗号分隔，倒序在字段前加 -",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc(正序), desc(倒序)",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type ModerationRequest struct {
+	Note string `json:"note,omitempty" valid:"note"`
+}
+
+// Moderation 处理举报，note 为处理说明，记录在处理记录中
+func Moderation(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"note": []string{"max_cn:255"},
+	}
+	messages := govalidator.MapData{
+		"note": []string{
+			"max_cn:处理说明长度需小于 255",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type ModerationLogFilterRequest struct {
+	TopicID     string `valid:"topic_id" form:"topic_id"`
+	ModeratorID string `valid:"moderator_id" form:"moderator_id"`
+	Action      string `valid:"action" form:"action"`
+	Sort        string `valid:"sort" form:"sort"`
+	Order       string `valid:"order" form:"order"`
+	PerPage     string `valid:"per_page" form:"per_page"`
+}
+
+// ModerationLogFilter 处理记录的过滤和排序参数
+func ModerationLogFilter(data interface{}, c *gin.Context) map[string][]string {
+	actions := strings.Join([]string{
+		moderation_log.ActionHide, moderation_log.ActionResolve, moderation_log.ActionDismiss, moderation_log.ActionAutoHide,
+	}, ",")
+
+	rules := govalidator.MapData{
+		"topic_id":     []string{"numeric"},
+		"moderator_id": []string{"numeric"},
+		"action":       []string{"in:" + actions},
+		"sort":         []string{"sort_fields:id,created_at"},
+		"order":        []string{"in:asc,desc"},
+		"per_page":     []string{"numeric_between:2,100"},
+	}
+	messages := govalidator.MapData{
+		"topic_id": []string{
+			"numeric:话题 ID 格式错误",
+		},
+		"moderator_id": []string{
+			"numeric:处理人 ID 格式错误",
+		},
+		"action": []string{
+			"in:操作仅支持 " + actions,
+		},
+		"sort": []string{
+			"sort_fields:排序字段仅支持 id,created_at，多个字段以逗号分隔，倒序在字段前加 -",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc(正序), desc(倒序)",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
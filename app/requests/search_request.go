@@ -0,0 +1,54 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type SearchRequest struct {
+	Q          string `valid:"q" form:"q"`
+	Type       string `valid:"type" form:"type"`
+	CategoryID string `valid:"category_id" form:"category_id"`
+	UserID     string `valid:"user_id" form:"user_id"`
+	From       string `valid:"from" form:"from"`
+	To         string `valid:"to" form:"to"`
+	PerPage    string `valid:"per_page" form:"per_page"`
+}
+
+func Search(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"q":           []string{"required", "max_cn:50"},
+		"type":        []string{"in:topic,user"},
+		"category_id": []string{"numeric"},
+		"user_id":     []string{"numeric"},
+		"from":        []string{"date"},
+		"to":          []string{"date"},
+		"per_page":    []string{"numeric_between:2,100"},
+	}
+
+	messages := govalidator.MapData{
+		"q": []string{
+			"required:搜索关键词为必填项",
+			"max_cn:搜索关键词长度需小于 50",
+		},
+		"type": []string{
+			"in:搜索类型仅支持 topic(话题), user(用户)",
+		},
+		"category_id": []string{
+			"numeric:分类 ID 格式错误",
+		},
+		"user_id": []string{
+			"numeric:作者 ID 格式错误",
+		},
+		"from": []string{
+			"date:开始日期格式错误，请使用 2006-01-02 格式",
+		},
+		"to": []string{
+			"date:结束日期格式错误，请使用 2006-01-02 格式",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -0,0 +1,48 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type TagPopularRequest struct {
+	Limit string `valid:"limit" form:"limit"`
+}
+
+// TagPopular 热门标签的数量
+func TagPopular(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"limit": []string{"numeric_between:1,100"},
+	}
+	messages := govalidator.MapData{
+		"limit": []string{
+			"numeric_between:数量的值介于 1~100 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type TagAutocompleteRequest struct {
+	Q     string `valid:"q" form:"q"`
+	Limit string `valid:"limit" form:"limit"`
+}
+
+// TagAutocomplete 标签自动补全，q 为已输入的内容
+func TagAutocomplete(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"q":     []string{"required", "max_cn:20"},
+		"limit": []string{"numeric_between:1,20"},
+	}
+	messages := govalidator.MapData{
+		"q": []string{
+			"required:请输入标签名称",
+			"max_cn:标签长度需小于 20",
+		},
+		"limit": []string{
+			"numeric_between:数量的值介于 1~20 之间",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -1,6 +1,18 @@
 package requests
 
 import (
+	"fmt"
+	"gohub/app/models/tag"
+	"gohub/app/models/topic"
+	"gohub/pkg/app"
+	"gohub/pkg/auth"
+	"gohub/pkg/config"
+	"gohub/pkg/filter"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/gin-gonic/gin"
 	"github.com/thedevsaddam/govalidator"
 )
@@ -9,14 +21,22 @@ type TopicRequest struct {
 	Title      string `json:"title,omitempty" valid:"title"`
 	Body       string `json:"body,omitempty" valid:"body"`
 	CategoryID string `json:"category_id,omitempty" valid:"category_id"`
+	// 标签名称，不传时不修改话题的标签，传空数组时清空标签
+	Tags []string `json:"tags" valid:"tags"`
+	// 发布状态，仅发布时有效，默认为直接发布，定时发布时需要 published_at
+	Status      string `json:"status,omitempty" valid:"status"`
+	PublishedAt string `json:"published_at,omitempty" valid:"published_at"`
 }
 
+// TopicSave 发布和编辑话题，标签名称规范化后验证，见 tag.Normalize
+// 正文链接过多，或短时间内重复发布相同内容时视为垃圾内容
 func TopicSave(data interface{}, c *gin.Context) map[string][]string {
 
 	rules := govalidator.MapData{
-		"title":       []string{"required", "min_cn:3", "max_cn:40"},
-		"body":        []string{"required", "min_cn:10", "max_cn:50000"},
+		"title":       []string{"required", "min_cn:3", "max_cn:40", "no_sensitive_words"},
+		"body":        []string{"required", "min_cn:10", "max_cn:50000", "no_sensitive_words"},
 		"category_id": []string{"required", "exists:categories,id"},
+		"status":      []string{"in:draft,published,scheduled"},
 	}
 	messages := govalidator.MapData{
 		"title": []string{
@@ -32,6 +52,130 @@ func TopicSave(data interface{}, c *gin.Context) map[string][]string {
 			"required:帖子分类为必填项",
 			"exists:帖子分类未找到",
 		},
+		"status": []string{
+			"in:发布状态仅支持 draft(草稿), published(发布), scheduled(定时发布)",
+		},
+	}
+	errs := validate(data, rules, messages)
+
+	_data := data.(*TopicRequest)
+	errs = checkSpam(c, errs, "body", _data.Title+"\n"+_data.Body)
+	if _data.Status == topic.StatusScheduled {
+		errs = checkPublishedAt(errs, _data.PublishedAt)
+	}
+
+	maxTags := config.GetInt("tag.max_per_topic")
+	if len(_data.Tags) > maxTags {
+		errs["tags"] = append(errs["tags"], fmt.Sprintf("最多只能添加 %d 个标签", maxTags))
+	}
+	maxLength := config.GetInt("tag.max_length")
+	for _, name := range _data.Tags {
+		normalized := tag.Normalize(name)
+		if len(tag.Slug(normalized)) == 0 {
+			errs["tags"] = append(errs["tags"], "标签 "+name+" 格式错误")
+		} else if utf8.RuneCountInString(normalized) > maxLength {
+			errs["tags"] = append(errs["tags"], fmt.Sprintf("标签 %v 长度需小于 %d", name, maxLength))
+		}
+	}
+	return errs
+}
+
+type TopicScheduleRequest struct {
+	PublishedAt string `json:"published_at,omitempty" valid:"published_at"`
+}
+
+// TopicSchedule 定时发布草稿，或修改定时发布的时间
+func TopicSchedule(data interface{}, c *gin.Context) map[string][]string {
+	_data := data.(*TopicScheduleRequest)
+	return checkPublishedAt(make(map[string][]string), _data.PublishedAt)
+}
+
+// checkPublishedAt 定时发布的时间必须是将来的时间，格式见 app.ParseDateTime
+func checkPublishedAt(errs map[string][]string, value string) map[string][]string {
+	if len(value) == 0 {
+		errs["published_at"] = append(errs["published_at"], "定时发布的时间为必填项")
+		return errs
+	}
+	publishedAt, err := app.ParseDateTime(value)
+	if err != nil {
+		errs["published_at"] = append(errs["published_at"], "时间格式错误，请使用 2006-01-02 15:04:05 格式")
+	} else if !publishedAt.After(time.Now()) {
+		errs["published_at"] = append(errs["published_at"], "定时发布的时间必须晚于当前时间")
+	}
+	return errs
+}
+
+// checkSpam 垃圾内容检查，content 为需要比较是否重复发布的内容，错误信息加到 field 字段上
+// 只有新发布时才检查重复，编辑时内容本来就与之前相同
+func checkSpam(c *gin.Context, errs map[string][]string, field, content string) map[string][]string {
+	if filter.TooManyLinks(content) {
+		errs[field] = append(errs[field], fmt.Sprintf("链接数量不能超过 %d 个", config.GetInt("filter.spam.max_links")))
+	}
+	if c.Request.Method == http.MethodPost && filter.IsDuplicate(auth.CurrentUID(c), content) {
+		errs[field] = append(errs[field], "请勿重复发布相同的内容")
+	}
+	return errs
+}
+
+type TopicFilterRequest struct {
+	CategoryID    string `valid:"category_id" form:"category_id"`
+	UserID        string `valid:"user_id" form:"user_id"`
+	CreatedAfter  string `valid:"created_after" form:"created_after"`
+	CreatedBefore string `valid:"created_before" form:"created_before"`
+	Sort          string `valid:"sort" form:"sort"`
+	Order         string `valid:"order" form:"order"`
+	PerPage       string `valid:"per_page" form:"per_page"`
+	Cursor        string `valid:"cursor" form:"cursor"`
+	Tag           string `valid:"tag" form:"tag"`
+}
+
+// TopicFilter 话题列表的过滤和排序参数，字段白名单见 topic.Filters 和 topic.SortFields
+// 带 cursor 参数时为游标分页，排序字段仅支持 topic.CursorSortFields
+func TopicFilter(data interface{}, c *gin.Context) map[string][]string {
+	sortFields := strings.Join(topic.SortFields, ",")
+	if _, ok := c.GetQuery("cursor"); ok {
+		sortFields = strings.Join(topic.CursorSortFields, ",")
+	}
+
+	rules := govalidator.MapData{
+		"category_id":    []string{"numeric"},
+		"user_id":        []string{"numeric"},
+		"created_after":  []string{"date"},
+		"created_before": []string{"date"},
+		"sort":           []string{"sort_fields:" + sortFields},
+		"order":          []string{"in:asc,desc"},
+		"per_page":       []string{"numeric_between:2,100"},
+		"cursor":         []string{"max:512"},
+		"tag":            []string{"max_cn:20"},
+	}
+	messages := govalidator.MapData{
+		"category_id": []string{
+			"numeric:分类 ID 格式错误",
+		},
+		"user_id": []string{
+			"numeric:作者 ID 格式错误",
+		},
+		"created_after": []string{
+			"date:日期格式错误，请使用 2006-01-02 格式",
+		},
+		"created_before": []string{
+			"date:日期格式错误，请使用 2006-01-02 格式",
+		},
+		"sort": []string{
+			"sort_fields:排序字段仅支持 " + sortFields + "，多个字段以逗号分隔，倒序在字段前加 -",
+		},
+		"order": []string{
+			"in:排序规则仅支持 asc(正序), desc(倒序)",
+		},
+		"per_page": []string{
+			"numeric_between:每页条数的值介于 2~100 之间",
+		},
+		"cursor": []string{
+			"max:游标格式错误",
+		},
+		"tag": []string{
+			"max_cn:标签长度需小于 20",
+		},
 	}
 	return validate(data, rules, messages)
 }
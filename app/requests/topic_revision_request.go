@@ -0,0 +1,23 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type TopicRevisionCompareRequest struct {
+	From string `valid:"from" form:"from"`
+}
+
+// TopicRevisionCompare 查看版本时对比的版本号，不传时与上一个版本对比
+func TopicRevisionCompare(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"from": []string{"numeric"},
+	}
+	messages := govalidator.MapData{
+		"from": []string{
+			"numeric:版本号格式错误",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -0,0 +1,71 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code,omitempty" valid:"code"`
+}
+
+// TwoFactorCode 验证身份验证器的验证码，用于确认开启和重新生成恢复码
+func TwoFactorCode(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"code": []string{"required", "digits:6"},
+	}
+	messages := govalidator.MapData{
+		"code": []string{
+			"required:验证码为必填项",
+			"digits:验证码长度必须为 6 位的数字",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password,omitempty" valid:"password"`
+	Code     string `json:"code,omitempty" valid:"code"`
+}
+
+// TwoFactorDisable 关闭两步验证需同时提供密码和验证码（或恢复码）
+func TwoFactorDisable(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"password": []string{"required", "min:6"},
+		"code":     []string{"required", "between:6,11"},
+	}
+	messages := govalidator.MapData{
+		"password": []string{
+			"required:密码为必填项",
+			"min:密码长度需大于 6",
+		},
+		"code": []string{
+			"required:验证码或恢复码为必填项",
+			"between:验证码或恢复码格式不正确",
+		},
+	}
+	return validate(data, rules, messages)
+}
+
+type LoginByTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token,omitempty" valid:"challenge_token"`
+	Code           string `json:"code,omitempty" valid:"code"`
+}
+
+// LoginByTwoFactor 登录第二步，code 可以是验证码，也可以是恢复码
+func LoginByTwoFactor(data interface{}, c *gin.Context) map[string][]string {
+	rules := govalidator.MapData{
+		"challenge_token": []string{"required"},
+		"code":            []string{"required", "between:6,11"},
+	}
+	messages := govalidator.MapData{
+		"challenge_token": []string{
+			"required:挑战令牌为必填项，参数名称 challenge_token",
+		},
+		"code": []string{
+			"required:验证码或恢复码为必填项",
+			"between:验证码或恢复码格式不正确",
+		},
+	}
+	return validate(data, rules, messages)
+}
@@ -19,9 +19,9 @@ func UserSave(data interface{}, c *gin.Context) map[string][]string {
 	// 查询用户名重复时，过滤掉当前用户 ID
 	uid := auth.CurrentUID(c)
 	rules := govalidator.MapData{
-		"name":         []string{"required", "alpha_num", "between:3,20", "not_exists:users,name," + uid},
-		"introduction": []string{"min_cn:4", "max_cn:240"},
-		"city":         []string{"min_cn:2", "max_cn:20"},
+		"name":         []string{"required", "alpha_num", "between:3,20", "not_exists:users,name," + uid, "no_sensitive_words"},
+		"introduction": []string{"min_cn:4", "max_cn:240", "no_sensitive_words"},
+		"city":         []string{"min_cn:2", "max_cn:20", "no_sensitive_words"},
 	}
 	messages := govalidator.MapData{
 		"name": []string{
@@ -43,8 +43,9 @@ func UserSave(data interface{}, c *gin.Context) map[string][]string {
 }
 
 type UserUpdateEmailRequest struct {
-	Email      string `json:"email,omitempty" valid:"email"`
-	VerifyCode string `json:"verify_code,omitempty" valid:"verify_code"`
+	Email         string `json:"email,omitempty" valid:"email"`
+	VerifyCode    string `json:"verify_code,omitempty" valid:"verify_code"`
+	OldVerifyCode string `json:"old_verify_code,omitempty" valid:"old_verify_code"`
 }
 
 func UserUpdateEmail(data interface{}, c *gin.Context) map[string][]string {
@@ -59,6 +60,10 @@ func UserUpdateEmail(data interface{}, c *gin.Context) map[string][]string {
 		},
 		"verify_code": []string{"required", "digits:6"},
 	}
+	// 已绑定 Email 时，还需要原 Email 收到的验证码
+	if len(currentUser.Email) > 0 {
+		rules["old_verify_code"] = []string{"required", "digits:6"}
+	}
 
 	messages := govalidator.MapData{
 		"email": []string{
@@ -73,18 +78,26 @@ func UserUpdateEmail(data interface{}, c *gin.Context) map[string][]string {
 			"required:验证码答案必填",
 			"digits:验证码长度必须为 6 位的数字",
 		},
+		"old_verify_code": []string{
+			"required:原 Email 的验证码必填",
+			"digits:验证码长度必须为 6 位的数字",
+		},
 	}
 
 	errs := validate(data, rules, messages)
 	_data := data.(*UserUpdateEmailRequest)
 	errs = validators.ValidateVerifyCode(_data.Email, _data.VerifyCode, errs)
+	if len(currentUser.Email) > 0 {
+		errs = validators.ValidateOldVerifyCode(currentUser.Email, _data.OldVerifyCode, errs)
+	}
 
 	return errs
 }
 
 type UserUpdatePhoneRequest struct {
-	Phone      string `json:"phone,omitempty" valid:"phone"`
-	VerifyCode string `json:"verify_code,omitempty" valid:"verify_code"`
+	Phone         string `json:"phone,omitempty" valid:"phone"`
+	VerifyCode    string `json:"verify_code,omitempty" valid:"verify_code"`
+	OldVerifyCode string `json:"old_verify_code,omitempty" valid:"old_verify_code"`
 }
 
 func UserUpdatePhone(data interface{}, c *gin.Context) map[string][]string {
@@ -98,6 +111,10 @@ func UserUpdatePhone(data interface{}, c *gin.Context) map[string][]string {
 		},
 		"verify_code": []string{"required", "digits:6"},
 	}
+	// 已绑定手机时，还需要原手机收到的验证码
+	if len(currentUser.Phone) > 0 {
+		rules["old_verify_code"] = []string{"required", "digits:6"}
+	}
 	messages := govalidator.MapData{
 		"phone": []string{
 			"required:手机号为必填项，参数名称 phone",
@@ -109,11 +126,18 @@ func UserUpdatePhone(data interface{}, c *gin.Context) map[string][]string {
 			"required:验证码答案必填",
 			"digits:验证码长度必须为 6 位的数字",
 		},
+		"old_verify_code": []string{
+			"required:原手机的验证码必填",
+			"digits:验证码长度必须为 6 位的数字",
+		},
 	}
 
 	errs := validate(data, rules, messages)
 	_data := data.(*UserUpdatePhoneRequest)
 	errs = validators.ValidateVerifyCode(_data.Phone, _data.VerifyCode, errs)
+	if len(currentUser.Phone) > 0 {
+		errs = validators.ValidateOldVerifyCode(currentUser.Phone, _data.OldVerifyCode, errs)
+	}
 	return errs
 }
 
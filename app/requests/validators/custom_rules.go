@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"gohub/pkg/database"
+	"gohub/pkg/filter"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/thedevsaddam/govalidator"
@@ -61,6 +63,7 @@ func init() {
 	// 一个使用场景是创建话题时需要附带 category_id 分类 ID 为参数，此时需要保证
 	// category_id 的值在数据库中存在，即可使用：
 	// exists:categories,id
+	// 表支持软删除时，回收站中的数据视为不存在
 	govalidator.AddCustomRule("exists", func(field, rule, message string, value interface{}) error {
 		rng := strings.Split(strings.TrimPrefix(rule, "exists:"), ",")
 
@@ -74,7 +77,11 @@ func init() {
 
 		// 查询数据库
 		var count int64
-		database.DB.Table(tableName).Where(dbFiled+" = ?", requestValue).Count(&count)
+		query := database.DB.Table(tableName).Where(dbFiled+" = ?", requestValue)
+		if hasSoftDeletes(tableName) {
+			query = query.Where("deleted_at IS NULL")
+		}
+		query.Count(&count)
 		// 验证不通过，数据不存在
 		if count == 0 {
 			// 如果有自定义错误消息的话，使用自定义消息
@@ -116,4 +123,59 @@ func init() {
 
 		return nil
 	})
+
+	// sort_fields:id,created_at 排序字段，支持逗号分隔的多个字段，字段前加 - 表示倒序
+	// 如 sort=-reply_count,created_at，所有字段都必须在白名单中
+	govalidator.AddCustomRule("sort_fields", func(field, rule, message string, value interface{}) error {
+		allowed := strings.Split(strings.TrimPrefix(rule, "sort_fields:"), ",")
+
+		for _, sortField := range strings.Split(value.(string), ",") {
+			column := strings.TrimPrefix(strings.TrimSpace(sortField), "-")
+			valid := false
+			for _, a := range allowed {
+				if column == a {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				// 如果有自定义错误消息的话，使用自定义消息
+				if message != "" {
+					return errors.New(message)
+				}
+				return fmt.Errorf("排序字段仅支持 %s", strings.Join(allowed, ","))
+			}
+		}
+
+		return nil
+	})
+
+	// no_sensitive_words 不能包含禁止发布的敏感词，即词表中处理方式为 reject 的词
+	// 处理方式为 mask 的词在模型保存时替换为 *，为 review 的词进入举报处理队列，见 pkg/filter
+	// 未设置自定义错误消息时，提示命中的敏感词
+	govalidator.AddCustomRule("no_sensitive_words", func(field, rule, message string, value interface{}) error {
+		words := filter.NewFilter().Check(value.(string)).Words(filter.ModeReject)
+		if len(words) > 0 {
+			// 如果有自定义错误消息的话，使用自定义消息
+			if message != "" {
+				return errors.New(message)
+			}
+			return fmt.Errorf("包含敏感词：%v", strings.Join(words, "、"))
+		}
+
+		return nil
+	})
+}
+
+// softDeleteTables 缓存各表是否有 deleted_at 字段，避免每次验证都查询表结构
+var softDeleteTables sync.Map
+
+// hasSoftDeletes 表是否支持软删除
+func hasSoftDeletes(tableName string) bool {
+	if has, ok := softDeleteTables.Load(tableName); ok {
+		return has.(bool)
+	}
+	has := database.DB.Migrator().HasColumn(tableName, "deleted_at")
+	softDeleteTables.Store(tableName, has)
+	return has
 }
@@ -28,3 +28,11 @@ func ValidateVerifyCode(key, answer string, errs map[string][]string) map[string
 	}
 	return errs
 }
+
+// ValidateOldVerifyCode 自定义规则，修改手机/邮箱时验证发送到『原手机/原邮箱』的验证码
+func ValidateOldVerifyCode(key, answer string, errs map[string][]string) map[string][]string {
+	if ok := verifycode.NewVerifyCode().CheckAnswer(key, answer); !ok {
+		errs["old_verify_code"] = append(errs["old_verify_code"], "原手机/邮箱的验证码错误")
+	}
+	return errs
+}
@@ -0,0 +1,24 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type VoteRequest struct {
+	Value string `json:"value,omitempty" valid:"value"`
+}
+
+func VoteSave(data interface{}, c *gin.Context) map[string][]string {
+
+	rules := govalidator.MapData{
+		"value": []string{"required", "in:up,down"},
+	}
+	messages := govalidator.MapData{
+		"value": []string{
+			"required:投票为必填项",
+			"in:投票仅支持 up(赞成), down(反对)",
+		},
+	}
+	return validate(data, rules, messages)
+}
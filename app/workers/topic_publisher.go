@@ -0,0 +1,64 @@
+// Package workers 后台任务，由 serve 命令随 Web 服务一起启动
+package workers
+
+import (
+	"context"
+	"fmt"
+	"gohub/app/models/follow"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/app/notifications"
+	"gohub/pkg/config"
+	"gohub/pkg/feed"
+	"gohub/pkg/logger"
+	"gohub/pkg/realtime"
+	"time"
+)
+
+// TopicPublished 话题发布后写入粉丝的时间线、推送给在线用户，并通知正文中 @ 到的用户（不通知作者自己）
+// 直接发布、发布草稿和定时发布时都会调用，保存草稿时不调用
+func TopicPublished(topicModel topic.Topic) {
+	follow.PublishActivity(feed.NewActivity(
+		feed.VerbTopicCreated, topicModel.UserID, topicModel.GetStringID(), topicModel.GetStringID(),
+	))
+	logger.LogIf(realtime.NewHub().Publish(realtime.ChannelTopics, "topic.created", topicModel))
+
+	author := user.Get(topicModel.UserID)
+	for _, userID := range topicModel.MentionedUserIDs {
+		if userID != author.GetStringID() {
+			notifications.Send(userID, notifications.TopicMentioned{Topic: topicModel, Author: author})
+		}
+	}
+}
+
+// PublishDueTopics 发布已到发布时间的定时话题，返回发布的话题数
+// 多个实例同时执行时，同一个话题只会被发布一次，见 topic.Publish
+func PublishDueTopics() (count int) {
+	for _, topicModel := range topic.DueScheduled(config.GetInt("topic.publish_batch", 100)) {
+		if topicModel.Publish() {
+			TopicPublished(topicModel)
+			count++
+		}
+	}
+	return
+}
+
+// RunTopicPublisher 按 topic.publish_interval 配置定时发布到期的话题，ctx 取消时退出
+func RunTopicPublisher(ctx context.Context) {
+	interval := config.GetInt("topic.publish_interval")
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if count := PublishDueTopics(); count > 0 {
+				logger.InfoString("Workers", "PublishDueTopics", fmt.Sprintf("published %d topics", count))
+			}
+		}
+	}
+}
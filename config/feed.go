@@ -0,0 +1,25 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("feed", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个用户的时间线（收件箱）最多保留的动态条数，更早的动态不再显示
+			"inbox_size": config.Env("FEED_INBOX_SIZE", 800),
+
+			// 每个用户的发件箱最多保留的动态条数，用于拉模式和关注时回填时间线
+			"outbox_size": 200,
+
+			// 粉丝数超过此值的用户发布动态时不再逐个写入粉丝的时间线（推模式），
+			// 只写入自己的发件箱，由粉丝读取时间线时合并（拉模式）
+			"fanout_threshold": config.Env("FEED_FANOUT_THRESHOLD", 1000),
+
+			// 关注用户时，将对方最近的动态回填到自己的时间线
+			"backfill": 20,
+
+			// 时间线每页的条数
+			"perpage": 20,
+		}
+	})
+}
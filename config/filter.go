@@ -0,0 +1,33 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("filter", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 敏感词词表的来源，支持 file 和 database
+			"source": config.Env("FILTER_SOURCE", "file"),
+
+			// source 为 file 时的词表文件，格式见 filter.File
+			"file": config.Env("FILTER_FILE", "storage/filter/words.txt"),
+
+			// source 为 database 时的词表数据表
+			"table": "sensitive_words",
+
+			// 词表未指定处理方式时的默认值，支持 reject（拒绝提交）、mask（替换为 *）和 review（进入审核队列）
+			"default_mode": "reject",
+
+			// 定时重新加载词表的间隔（秒），设为 0 只在启动时加载
+			"reload_interval": 60,
+
+			// 垃圾内容识别
+			"spam": map[string]interface{}{
+				// 话题和回复最多的链接数，设为 0 不限制
+				"max_links": 5,
+
+				// 同一用户在此时间（秒）内不能重复发布相同的内容，设为 0 不限制
+				"duplicate_window": 600,
+			},
+		}
+	})
+}
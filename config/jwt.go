@@ -5,8 +5,20 @@ import "gohub/pkg/config"
 func init() {
 	config.Add("jwt", func() map[string]interface{} {
 		return map[string]interface{}{
-			// 使用 config.GetString("app.key")
-			// "signing_key":
+			// 签名算法，支持 HS256, HS384, HS512, RS256, RS384, RS512, ES256, ES384, ES512, EdDSA
+			// HS 系列使用 config.GetString("app.key") 作为秘钥，其他算法使用 private_key 私钥签名
+			"algorithm": config.Env("JWT_ALGORITHM", "HS256"),
+
+			// 当前签名使用的 PEM 格式私钥，可使用 `jwt-key` 命令生成
+			// 令牌头部的 kid 为对应公钥的 JWK Thumbprint
+			"private_key": config.Env("JWT_PRIVATE_KEY", "storage/jwt/private.pem"),
+
+			// 验签公钥目录，目录下所有 .pem 文件都可用于验签，公钥同时通过 /.well-known/jwks.json 对外公开
+			// 轮换密钥时，将旧公钥留在此目录，直到旧令牌全部过期
+			"public_keys_path": config.Env("JWT_PUBLIC_KEYS_PATH", "storage/jwt/public"),
+
+			// 使用非对称算法时，是否仍接受 app.key 签发的 HS256 令牌，从 HS256 迁移时使用
+			"accept_hmac": config.Env("JWT_ACCEPT_HMAC", false),
 
 			// 过期时间，单位是分钟，一般不超过两个小时
 			"expire_time": config.Env("JWT_EXPIRE_TIME", 120),
@@ -0,0 +1,24 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("lockout", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 登录失败多少次后需要输入图片验证码，为 0 时始终需要
+			"captcha_threshold": config.Env("LOCKOUT_CAPTCHA_THRESHOLD", 3),
+
+			// 登录失败多少次后锁定账号
+			"lock_threshold": config.Env("LOCKOUT_LOCK_THRESHOLD", 5),
+
+			// 首次锁定的时长，之后每失败一次时长翻倍，单位是分钟
+			"lock_time": config.Env("LOCKOUT_LOCK_TIME", 1),
+
+			// 单次锁定的最长时长，单位是分钟
+			"max_lock_time": config.Env("LOCKOUT_MAX_LOCK_TIME", 1440),
+
+			// 失败次数的统计周期，从第一次失败算起，单位是分钟
+			"decay_time": config.Env("LOCKOUT_DECAY_TIME", 1440),
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("message", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个用户发送私信的频率限制，格式见 middlewares.LimitIP，如 30-M 为每分钟 30 条
+			"send_limit": config.Env("MESSAGE_SEND_LIMIT", "30-M"),
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("moderation", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 话题待处理举报的独立举报人数达到此值时自动隐藏，等待版主处理，设为 0 不自动隐藏
+			"auto_hide_threshold": config.Env("MODERATION_AUTO_HIDE_THRESHOLD", 5),
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("notify", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 站内信的表名称
+			"table": "notifications",
+
+			// 各类通知默认的发送渠道，多个渠道以逗号分隔，可选 database、mail 和 sms
+			// 用户可通过 /notifications/preferences 修改
+			"defaults": map[string]interface{}{
+				"topic_replied":    "database,mail",
+				"reply_replied":    "database",
+				"topic_voted":      "database",
+				"topic_mentioned":  "database",
+				"message_received": "database",
+			},
+
+			// 通知短信的模板，模板变量为 name（操作人）和 title（话题标题）
+			"sms_template_code": config.Env("SMS_ALIYUN_NOTIFY_TEMPLATE_CODE"),
+		}
+	})
+}
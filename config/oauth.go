@@ -0,0 +1,46 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("oauth", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 授权回调地址，{provider} 会被替换为平台名称，需与第三方平台后台填写的一致
+			"callback_url": config.Env("OAUTH_CALLBACK_URL", config.Env("APP_URL", "http://localhost:3000").(string)+"/api/v1/auth/oauth/{provider}/callback"),
+
+			// 从发起授权到回调的有效期，单位是分钟
+			"state_expire_time": 10,
+
+			// 未配置 client_id 的平台不会启用
+			"github": map[string]interface{}{
+				"client_id":     config.Env("OAUTH_GITHUB_CLIENT_ID", ""),
+				"client_secret": config.Env("OAUTH_GITHUB_CLIENT_SECRET", ""),
+			},
+
+			"google": map[string]interface{}{
+				"client_id":     config.Env("OAUTH_GOOGLE_CLIENT_ID", ""),
+				"client_secret": config.Env("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+			},
+
+			// 微信开放平台的网站应用
+			"wechat": map[string]interface{}{
+				"app_id":     config.Env("OAUTH_WECHAT_APP_ID", ""),
+				"app_secret": config.Env("OAUTH_WECHAT_APP_SECRET", ""),
+			},
+
+			// 通用的 OpenID Connect 平台，如 Keycloak、Authing
+			"oidc": map[string]interface{}{
+				"name":          config.Env("OAUTH_OIDC_NAME", "oidc"),
+				"issuer":        config.Env("OAUTH_OIDC_ISSUER", ""),
+				"client_id":     config.Env("OAUTH_OIDC_CLIENT_ID", ""),
+				"client_secret": config.Env("OAUTH_OIDC_CLIENT_SECRET", ""),
+			},
+
+			// 本地模拟平台，方便离线调试，切勿在生产环境开启
+			"fake": map[string]interface{}{
+				"enabled":       config.Env("OAUTH_FAKE_ENABLED", false),
+				"authorize_url": config.Env("APP_URL", "http://localhost:3000").(string) + "/api/v1/auth/oauth-fake/authorize",
+			},
+		}
+	})
+}
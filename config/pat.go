@@ -0,0 +1,21 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("pat", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 个人访问令牌的前缀，便于识别令牌类型，也方便代码仓库的密钥扫描工具检测泄露
+			"prefix": config.Env("PAT_PREFIX", "gohub_pat_"),
+
+			// 单个令牌默认的限流规则，格式与 middlewares.LimitIP 相同
+			"rate_limit": config.Env("PAT_RATE_LIMIT", "1000-H"),
+
+			// 令牌最长有效期，单位是天
+			"max_expire_days": 365,
+
+			// 最后使用时间的更新间隔，单位是秒，避免每个请求都写数据库
+			"touch_interval": 60,
+		}
+	})
+}
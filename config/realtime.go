@@ -0,0 +1,24 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("realtime", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 事件分发方式，redis 使用 Redis 发布订阅，支持多个服务器实例；memory 只适用于单个实例
+			"broker": config.Env("REALTIME_BROKER", "redis"),
+
+			// 每个连接待推送事件的缓冲数量，客户端处理太慢、缓冲已满时断开连接
+			"buffer": 64,
+
+			// 心跳间隔（秒），WebSocket 发送 ping，SSE 发送注释行；超过两个间隔没有收到客户端的帧时断开
+			"ping_interval": 30,
+
+			// 客户端发送的 WebSocket 消息的最大字节数，客户端只发送订阅指令
+			"max_message": 4096,
+
+			// 每个连接最多订阅的频道数
+			"max_channels": 20,
+		}
+	})
+}
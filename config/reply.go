@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("reply", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 回复允许嵌套的最大层级，直接回复话题为第 1 层
+			// 回复已达最大层级的回复时，新回复挂在同一层级，不再继续嵌套
+			"max_depth": config.Env("REPLY_MAX_DEPTH", 3),
+
+			// 回复列表每页的楼层数（第 1 层回复的数量）
+			"perpage": 20,
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("search", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 全文搜索驱动，支持 database、mysql、sqlite 和 memory
+			// database 根据 database.connection 自动选择 mysql 或 sqlite
+			// sqlite 驱动依赖 FTS5 扩展，编译时需加上 -tags sqlite_fts5
+			"driver": config.Env("SEARCH_DRIVER", "database"),
+
+			// mysql 和 sqlite 驱动的索引表名称
+			"table": "search_documents",
+
+			// memory 驱动为进程内的倒排索引，持久化到此文件
+			"memory": map[string]interface{}{
+				"path": config.Env("SEARCH_MEMORY_PATH", "storage/search/index.gob"),
+			},
+
+			// 搜索结果高亮
+			"highlight": map[string]interface{}{
+				"pre_tag":  "<em>",
+				"post_tag": "</em>",
+				// 摘要片段的长度（字符数）
+				"length": 120,
+			},
+
+			// search:reindex 命令每批读取的数据条数
+			"chunk_size": 200,
+		}
+	})
+}
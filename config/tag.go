@@ -0,0 +1,39 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("tag", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个话题最多的标签数
+			"max_per_topic": 5,
+
+			// 标签名称最大长度（字符数）
+			"max_length": 20,
+
+			// 标签别名，key 为别名（小写，不能包含 .），value 为标准名称，
+			// 如话题添加 golang 或 GO 标签时，实际添加的都是 Go 标签
+			"aliases": map[string]interface{}{
+				"go":         "Go",
+				"golang":     "Go",
+				"go语言":       "Go",
+				"javascript": "JavaScript",
+				"js":         "JavaScript",
+				"typescript": "TypeScript",
+				"ts":         "TypeScript",
+				"python":     "Python",
+				"py":         "Python",
+				"kubernetes": "Kubernetes",
+				"k8s":        "Kubernetes",
+				"mysql":      "MySQL",
+				"redis":      "Redis",
+				"frontend":   "前端",
+				"backend":    "后端",
+				"database":   "数据库",
+				"db":         "数据库",
+				"algorithm":  "算法",
+				"算法题":        "算法",
+			},
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("topic", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 热度排序（sort=-hot_score）的计算参数，见 topic.HotScore
+			"hot": map[string]interface{}{
+				// 得分 = 赞成票减反对票 * vote_weight + 收藏数 * favorite_weight + 回复数 * reply_weight
+				"vote_weight":     1,
+				"favorite_weight": 2,
+				"reply_weight":    1,
+
+				// 热度衰减周期（秒），晚发布 decay 秒的话题，得分少 10 倍也能排在前面
+				"decay": 45000,
+			},
+
+			// 话题列表返回的摘要长度（字符数），不超过 250，摘要由渲染后的正文生成，不含代码块
+			"excerpt_length": 140,
+
+			// 定时发布任务的执行间隔（秒），每次最多发布 publish_batch 个到期的话题，设为 0 不运行定时发布
+			"publish_interval": 60,
+			"publish_batch":    100,
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("two_factor", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 身份验证器中显示的发行方名称，默认使用应用名称
+			"issuer": config.Env("TWO_FACTOR_ISSUER", config.Env("APP_NAME", "Gohub")),
+
+			// 登录第二步的挑战令牌有效期，单位是分钟
+			"challenge_expire_time": config.Env("TWO_FACTOR_CHALLENGE_EXPIRE", 5),
+
+			// 每个挑战令牌允许输错验证码的次数，超过后需重新输入密码
+			"max_attempts": 5,
+
+			// 允许前后偏差的时间步数量，每步 30 秒，用于容忍手机与服务器的时间误差
+			"skew": 1,
+
+			// 每次生成的恢复码数量
+			"recovery_codes": 8,
+		}
+	})
+}
@@ -11,6 +11,9 @@ func init() {
 			// 过期时间，单位是分钟
 			"expire_time": config.Env("VERIFY_CODE_EXPIRE", 15),
 
+			// Email 验证链接的过期时间，单位是分钟
+			"link_expire_time": config.Env("VERIFY_LINK_EXPIRE", 60),
+
 			// debug 模式下的过期时间，方便本地开发调试
 			"debug_expire_time": 10080,
 			// 本地开发环境验证码使用 debug_code
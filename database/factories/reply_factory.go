@@ -0,0 +1,25 @@
+package factories
+
+import (
+	"gohub/app/models/reply"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/spf13/cast"
+)
+
+func MakeReplies(count int) []reply.Reply {
+
+	var objs []reply.Reply
+
+	for i := 0; i < count; i++ {
+		replyModel := reply.Reply{
+			Body:    faker.Paragraph(),
+			TopicID: cast.ToString(i%10 + 1),
+			UserID:  "1",
+			Depth:   1,
+		}
+		objs = append(objs, replyModel)
+	}
+
+	return objs
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Permission struct {
+		models.BaseModel
+
+		Name        string `gorm:"type:varchar(100);not null;unique"`
+		Description string `gorm:"type:varchar(255);default:null"`
+
+		models.CommonTimestampsField
+	}
+
+	type Role struct {
+		models.BaseModel
+
+		Name        string       `gorm:"type:varchar(100);not null;unique"`
+		Description string       `gorm:"type:varchar(255);default:null"`
+		Permissions []Permission `gorm:"many2many:role_permissions;"`
+
+		models.CommonTimestampsField
+	}
+
+	type User struct {
+		models.BaseModel
+
+		Roles []Role `gorm:"many2many:user_roles;"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 同时创建 role_permissions 和 user_roles 中间表
+		migrator.AutoMigrate(&Permission{}, &Role{}, &User{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable("user_roles", "role_permissions", &Role{}, &Permission{})
+	}
+
+	migrate.Add("2026_10_18_101500_add_roles_and_permissions_tables", up, down)
+}
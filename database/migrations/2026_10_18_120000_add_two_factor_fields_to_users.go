@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		TwoFactorSecret        string     `gorm:"type:varchar(64);default:null"`
+		TwoFactorRecoveryCodes string     `gorm:"type:text;default:null"`
+		TwoFactorConfirmedAt   *time.Time `gorm:"default:null"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&User{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropColumn(&User{}, "TwoFactorSecret")
+		migrator.DropColumn(&User{}, "TwoFactorRecoveryCodes")
+		migrator.DropColumn(&User{}, "TwoFactorConfirmedAt")
+	}
+
+	migrate.Add("2026_10_18_120000_add_two_factor_fields_to_users", up, down)
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type UserIdentity struct {
+		models.BaseModel
+
+		// 每个平台只能绑定一个账号，同一个第三方账号只能绑定一个用户
+		UserID   string `gorm:"type:bigint;not null;uniqueIndex:idx_user_identities_user_provider"`
+		Provider string `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_user_provider;uniqueIndex:idx_user_identities_provider_subject"`
+		Subject  string `gorm:"type:varchar(191);not null;uniqueIndex:idx_user_identities_provider_subject"`
+		Name     string `gorm:"type:varchar(255);default:null"`
+		Email    string `gorm:"type:varchar(255);default:null"`
+		Avatar   string `gorm:"type:varchar(255);default:null"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&UserIdentity{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&UserIdentity{})
+	}
+
+	migrate.Add("2026_10_18_140000_add_user_identities_table", up, down)
+}
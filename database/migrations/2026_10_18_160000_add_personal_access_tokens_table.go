@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		models.BaseModel
+	}
+
+	type PersonalAccessToken struct {
+		models.BaseModel
+
+		UserID     string     `gorm:"type:bigint;not null;index"`
+		Name       string     `gorm:"type:varchar(100);not null"`
+		Prefix     string     `gorm:"type:varchar(32);not null"`
+		Token      string     `gorm:"type:char(64);not null;unique"`
+		Scopes     string     `gorm:"type:varchar(255);not null"`
+		RateLimit  string     `gorm:"type:varchar(20);default:null"`
+		ExpiresAt  *time.Time `gorm:"default:null"`
+		LastUsedAt *time.Time `gorm:"default:null"`
+		LastUsedIP string     `gorm:"type:varchar(45);default:null"`
+		User       User
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&PersonalAccessToken{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&PersonalAccessToken{})
+	}
+
+	migrate.Add("2026_10_18_160000_add_personal_access_tokens_table", up, down)
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		EmailVerifiedAt *time.Time `gorm:"default:null"`
+		PhoneVerifiedAt *time.Time `gorm:"default:null"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&User{})
+
+		// 已有用户的手机和 Email 都是在注册或修改时通过验证码确认过的
+		_, err := DB.Exec("UPDATE users SET email_verified_at = created_at WHERE email IS NOT NULL AND email != ''")
+		console.ExitIf(err)
+		_, err = DB.Exec("UPDATE users SET phone_verified_at = created_at WHERE phone IS NOT NULL AND phone != ''")
+		console.ExitIf(err)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropColumn(&User{}, "EmailVerifiedAt")
+		migrator.DropColumn(&User{}, "PhoneVerifiedAt")
+	}
+
+	migrate.Add("2026_10_18_180000_add_verified_at_fields_to_users", up, down)
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type SoftDeletes struct {
+		DeletedAt gorm.DeletedAt `gorm:"index"`
+	}
+	type User struct {
+		SoftDeletes
+	}
+	type Category struct {
+		SoftDeletes
+	}
+	type Topic struct {
+		SoftDeletes
+	}
+	type Link struct {
+		SoftDeletes
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&User{}, &Category{}, &Topic{}, &Link{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropColumn(&User{}, "DeletedAt")
+		migrator.DropColumn(&Category{}, "DeletedAt")
+		migrator.DropColumn(&Topic{}, "DeletedAt")
+		migrator.DropColumn(&Link{}, "DeletedAt")
+	}
+
+	migrate.Add("2026_10_18_200000_add_deleted_at_fields", up, down)
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		models.BaseModel
+	}
+
+	type Topic struct {
+		models.BaseModel
+		ReplyCount  int64      `gorm:"not null;default:0;index"`
+		LastReplyAt *time.Time `gorm:"default:null;index"`
+	}
+
+	type Reply struct {
+		models.BaseModel
+		TopicID  string `gorm:"type:bigint;not null;index"`
+		UserID   string `gorm:"type:bigint;not null;index"`
+		ParentID string `gorm:"type:bigint;not null;default:0;index"`
+		RootID   string `gorm:"type:bigint;not null;default:0;index"`
+		Depth    int    `gorm:"not null;default:1"`
+		Body     string `gorm:"type:text;not null"`
+		User     User
+
+		models.CommonTimestampsField
+		models.SoftDeletes
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Topic{}, &Reply{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Reply{})
+		migrator.DropColumn(&Topic{}, "ReplyCount")
+		migrator.DropColumn(&Topic{}, "LastReplyAt")
+	}
+
+	migrate.Add("2026_10_18_220000_add_replies_table", up, down)
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/config"
+	"gohub/pkg/console"
+	"gohub/pkg/database"
+	"gohub/pkg/migrate"
+	"gohub/pkg/search"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	// 索引表的结构由搜索驱动决定（MySQL 普通表或 SQLite FTS5 虚拟表），memory 驱动不需要数据表
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		if err := search.NewSearch().Driver.Migrate(database.DB); err != nil {
+			console.Warning("Full-text search index table not created: " + err.Error())
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(config.GetString("search.table", "search_documents"))
+	}
+
+	migrate.Add("2026_10_18_230000_add_search_documents_table", up, down)
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Topic struct {
+		models.BaseModel
+		UpvoteCount   int64   `gorm:"not null;default:0"`
+		DownvoteCount int64   `gorm:"not null;default:0"`
+		FavoriteCount int64   `gorm:"not null;default:0;index"`
+		HotScore      float64 `gorm:"not null;default:0;index"`
+	}
+
+	type Vote struct {
+		models.BaseModel
+		UserID  string `gorm:"type:bigint;not null;uniqueIndex:idx_votes_user_topic"`
+		TopicID string `gorm:"type:bigint;not null;uniqueIndex:idx_votes_user_topic;index"`
+		Value   int    `gorm:"type:tinyint;not null"`
+
+		models.CommonTimestampsField
+	}
+
+	type Favorite struct {
+		models.BaseModel
+		UserID  string `gorm:"type:bigint;not null;uniqueIndex:idx_favorites_user_topic"`
+		TopicID string `gorm:"type:bigint;not null;uniqueIndex:idx_favorites_user_topic;index"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Topic{}, &Vote{}, &Favorite{})
+
+		// 已有话题没有投票和收藏，按发布时间和回复数计算初始热度，
+		// 权重和衰减周期使用默认配置，与 topic.HotScore 一致
+		rows, err := DB.Query("SELECT id, created_at, reply_count FROM topics")
+		console.ExitIf(err)
+		type hotScore struct {
+			id    uint64
+			score float64
+		}
+		var scores []hotScore
+		for rows.Next() {
+			var (
+				id         uint64
+				createdAt  time.Time
+				replyCount int64
+			)
+			console.ExitIf(rows.Scan(&id, &createdAt, &replyCount))
+			score := math.Log10(math.Max(float64(replyCount), 1)) + float64(createdAt.Unix())/45000
+			scores = append(scores, hotScore{id, score})
+		}
+		rows.Close()
+		for _, s := range scores {
+			_, err := DB.Exec("UPDATE topics SET hot_score = ? WHERE id = ?", s.score, s.id)
+			console.ExitIf(err)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Vote{}, &Favorite{})
+		migrator.DropColumn(&Topic{}, "UpvoteCount")
+		migrator.DropColumn(&Topic{}, "DownvoteCount")
+		migrator.DropColumn(&Topic{}, "FavoriteCount")
+		migrator.DropColumn(&Topic{}, "HotScore")
+	}
+
+	migrate.Add("2026_10_19_100000_add_votes_and_favorites_tables", up, down)
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type User struct {
+		models.BaseModel
+		FollowersCount  int64 `gorm:"not null;default:0;index"`
+		FollowingsCount int64 `gorm:"not null;default:0"`
+	}
+
+	type Follow struct {
+		models.BaseModel
+		FollowerID  string `gorm:"type:bigint;not null;uniqueIndex:idx_follows_follower_following"`
+		FollowingID string `gorm:"type:bigint;not null;uniqueIndex:idx_follows_follower_following;index"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&User{}, &Follow{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Follow{})
+		migrator.DropColumn(&User{}, "FollowersCount")
+		migrator.DropColumn(&User{}, "FollowingsCount")
+	}
+
+	migrate.Add("2026_10_19_120000_add_follows_table", up, down)
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Notification struct {
+		models.BaseModel
+		UserID string     `gorm:"type:bigint;not null;index:idx_notifications_user_read"`
+		Type   string     `gorm:"type:varchar(50);not null"`
+		Data   string     `gorm:"type:text;not null"`
+		ReadAt *time.Time `gorm:"default:null;index:idx_notifications_user_read"`
+
+		models.CommonTimestampsField
+	}
+
+	type NotificationPreference struct {
+		models.BaseModel
+		UserID   string `gorm:"type:bigint;not null;uniqueIndex:idx_notification_preferences_user_type"`
+		Type     string `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_preferences_user_type"`
+		Channels string `gorm:"type:varchar(100);not null;default:''"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Notification{}, &NotificationPreference{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Notification{}, &NotificationPreference{})
+	}
+
+	migrate.Add("2026_10_19_140000_add_notifications_tables", up, down)
+}
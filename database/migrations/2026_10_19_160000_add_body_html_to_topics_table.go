@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/console"
+	"gohub/pkg/markdown"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Topic struct {
+		models.BaseModel
+		BodyHTML string `gorm:"column:body_html;type:longtext"`
+		Excerpt  string `gorm:"type:varchar(255);not null;default:''"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Topic{})
+
+		// 渲染已有话题的正文，与 topic.renderBody 一致，摘要长度使用默认配置
+		rows, err := DB.Query("SELECT id, body FROM topics")
+		console.ExitIf(err)
+		type topicBody struct {
+			id   uint64
+			body string
+		}
+		var topics []topicBody
+		for rows.Next() {
+			var (
+				id   uint64
+				body string
+			)
+			console.ExitIf(rows.Scan(&id, &body))
+			topics = append(topics, topicBody{id, body})
+		}
+		rows.Close()
+
+		options := markdown.Options{
+			MentionURL: func(name string) (string, bool) {
+				var id string
+				err := DB.QueryRow("SELECT id FROM users WHERE name = ?", name).Scan(&id)
+				return "/users/" + id, err == nil
+			},
+			TopicURL: func(id string) (string, bool) {
+				var count int64
+				console.ExitIf(DB.QueryRow("SELECT COUNT(*) FROM topics WHERE id = ?", id).Scan(&count))
+				return "/topics/" + id, count > 0
+			},
+		}
+		for _, t := range topics {
+			result := markdown.Render(t.body, options)
+			_, err := DB.Exec("UPDATE topics SET body_html = ?, excerpt = ? WHERE id = ?",
+				result.HTML, markdown.Excerpt(result.HTML, 140), t.id)
+			console.ExitIf(err)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropColumn(&Topic{}, "BodyHTML")
+		migrator.DropColumn(&Topic{}, "Excerpt")
+	}
+
+	migrate.Add("2026_10_19_160000_add_body_html_to_topics_table", up, down)
+}
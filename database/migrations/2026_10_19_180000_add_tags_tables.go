@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Tag struct {
+		models.BaseModel
+		Name       string `gorm:"type:varchar(255);not null"`
+		Slug       string `gorm:"type:varchar(255);not null;unique"`
+		TopicCount int64  `gorm:"not null;default:0;index"`
+
+		models.CommonTimestampsField
+	}
+
+	type TopicTag struct {
+		TopicID uint64 `gorm:"primaryKey;autoIncrement:false"`
+		TagID   uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Tag{}, &TopicTag{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&TopicTag{}, &Tag{})
+	}
+
+	migrate.Add("2026_10_19_180000_add_tags_tables", up, down)
+}
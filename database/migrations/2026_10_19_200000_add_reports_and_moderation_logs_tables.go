@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Report struct {
+		models.BaseModel
+		ReporterID  string     `gorm:"type:bigint;not null;uniqueIndex:idx_reports_reporter_topic"`
+		TopicID     string     `gorm:"type:bigint;not null;uniqueIndex:idx_reports_reporter_topic;index:idx_reports_topic_status"`
+		Reason      string     `gorm:"type:varchar(20);not null"`
+		Description string     `gorm:"type:varchar(1000);not null;default:''"`
+		Status      string     `gorm:"type:varchar(20);not null;index:idx_reports_topic_status;index"`
+		HandledAt   *time.Time `gorm:"default:null"`
+
+		models.CommonTimestampsField
+	}
+
+	type ModerationLog struct {
+		models.BaseModel
+		ModeratorID string `gorm:"type:bigint;not null;index"`
+		TopicID     string `gorm:"type:bigint;not null;index"`
+		Action      string `gorm:"type:varchar(20);not null"`
+		ReportCount int64  `gorm:"not null;default:0"`
+		Note        string `gorm:"type:varchar(1000);not null;default:''"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Report{}, &ModerationLog{})
+
+		// 已有的权限数据由 SeedRolesTable 生成，这里补充处理举报的权限，并授予版主
+		now := time.Now()
+		_, err := DB.Exec("INSERT INTO permissions (name, description, created_at, updated_at) "+
+			"SELECT ?, ?, ?, ? FROM roles WHERE name = ? AND NOT EXISTS (SELECT 1 FROM permissions WHERE name = ?)",
+			"report.moderate", "处理举报", now, now, "moderator", "report.moderate")
+		console.ExitIf(err)
+		_, err = DB.Exec("INSERT INTO role_permissions (role_id, permission_id) "+
+			"SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = ? AND permissions.name = ? "+
+			"AND NOT EXISTS (SELECT 1 FROM role_permissions WHERE role_id = roles.id AND permission_id = permissions.id)",
+			"moderator", "report.moderate")
+		console.ExitIf(err)
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Report{}, &ModerationLog{})
+
+		_, err := DB.Exec("DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE name = ?)", "report.moderate")
+		console.ExitIf(err)
+		_, err = DB.Exec("DELETE FROM permissions WHERE name = ?", "report.moderate")
+		console.ExitIf(err)
+	}
+
+	migrate.Add("2026_10_19_200000_add_reports_and_moderation_logs_tables", up, down)
+}
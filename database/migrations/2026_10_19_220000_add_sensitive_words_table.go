@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type SensitiveWord struct {
+		models.BaseModel
+		Word string `gorm:"type:varchar(255);not null;unique"`
+		// 处理方式 reject、mask 或 review，为空时使用 filter.default_mode 配置
+		Mode string `gorm:"type:varchar(20);not null;default:''"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&SensitiveWord{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&SensitiveWord{})
+	}
+
+	migrate.Add("2026_10_19_220000_add_sensitive_words_table", up, down)
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Conversation struct {
+		models.BaseModel
+		UserID            string     `gorm:"type:bigint;not null;uniqueIndex:idx_conversations_user_peer"`
+		PeerID            string     `gorm:"type:bigint;not null;uniqueIndex:idx_conversations_user_peer"`
+		UnreadCount       int64      `gorm:"not null;default:0"`
+		LastReadMessageID string     `gorm:"type:bigint;not null;default:0"`
+		LastMessageID     string     `gorm:"type:bigint;not null;default:0"`
+		LastMessage       string     `gorm:"type:varchar(255)"`
+		LastMessageAt     *time.Time `gorm:"index"`
+
+		models.CommonTimestampsField
+	}
+
+	type Message struct {
+		models.BaseModel
+		SenderID    string `gorm:"type:bigint;not null;index:idx_messages_sender_recipient"`
+		RecipientID string `gorm:"type:bigint;not null;index:idx_messages_sender_recipient;index"`
+		Body        string `gorm:"type:text;not null"`
+
+		models.CommonTimestampsField
+	}
+
+	type Block struct {
+		models.BaseModel
+		UserID    string `gorm:"type:bigint;not null;uniqueIndex:idx_blocks_user_blocked"`
+		BlockedID string `gorm:"type:bigint;not null;uniqueIndex:idx_blocks_user_blocked;index"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Conversation{}, &Message{}, &Block{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&Block{}, &Message{}, &Conversation{})
+	}
+
+	migrate.Add("2026_10_20_100000_add_messages_tables", up, down)
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Topic struct {
+		models.BaseModel
+		Status      string     `gorm:"type:varchar(20);not null;default:'published';index:idx_topics_status_published_at"`
+		PublishedAt *time.Time `gorm:"index:idx_topics_status_published_at"`
+		Pinned      bool       `gorm:"not null;default:false"`
+		Locked      bool       `gorm:"not null;default:false"`
+	}
+
+	// 置顶和锁定话题的权限
+	permissions := map[string]string{
+		"topic.pin":  "置顶话题",
+		"topic.lock": "锁定话题",
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Topic{})
+
+		// 已有的话题都是已发布的，发布时间即创建时间
+		_, err := DB.Exec("UPDATE topics SET published_at = created_at WHERE published_at IS NULL")
+		console.ExitIf(err)
+
+		// 已有的权限数据由 SeedRolesTable 生成，这里补充置顶和锁定的权限，并授予版主
+		now := time.Now()
+		for name, description := range permissions {
+			_, err := DB.Exec("INSERT INTO permissions (name, description, created_at, updated_at) "+
+				"SELECT ?, ?, ?, ? FROM roles WHERE name = ? AND NOT EXISTS (SELECT 1 FROM permissions WHERE name = ?)",
+				name, description, now, now, "moderator", name)
+			console.ExitIf(err)
+			_, err = DB.Exec("INSERT INTO role_permissions (role_id, permission_id) "+
+				"SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = ? AND permissions.name = ? "+
+				"AND NOT EXISTS (SELECT 1 FROM role_permissions WHERE role_id = roles.id AND permission_id = permissions.id)",
+				"moderator", name)
+			console.ExitIf(err)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropIndex(&Topic{}, "idx_topics_status_published_at")
+		for _, column := range []string{"Status", "PublishedAt", "Pinned", "Locked"} {
+			migrator.DropColumn(&Topic{}, column)
+		}
+
+		for name := range permissions {
+			_, err := DB.Exec("DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE name = ?)", name)
+			console.ExitIf(err)
+			_, err = DB.Exec("DELETE FROM permissions WHERE name = ?", name)
+			console.ExitIf(err)
+		}
+	}
+
+	migrate.Add("2026_10_20_120000_add_publishing_fields_to_topics_table", up, down)
+}
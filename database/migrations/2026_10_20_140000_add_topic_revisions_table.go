@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type TopicRevision struct {
+		models.BaseModel
+		TopicID string `gorm:"type:bigint;not null;uniqueIndex:idx_topic_revisions_topic_version"`
+		Version int64  `gorm:"not null;uniqueIndex:idx_topic_revisions_topic_version"`
+		UserID  string `gorm:"type:bigint;not null;index"`
+		Title   string `gorm:"type:varchar(255);not null"`
+		Body    string `gorm:"type:longtext;not null"`
+		Note    string `gorm:"type:varchar(255);not null;default:''"`
+
+		models.CommonTimestampsField
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&TopicRevision{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropTable(&TopicRevision{})
+	}
+
+	migrate.Add("2026_10_20_140000_add_topic_revisions_table", up, down)
+}
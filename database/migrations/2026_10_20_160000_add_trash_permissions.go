@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	// 删除用户和链接的权限，以及回收站的查看、恢复和彻底删除权限
+	permissions := map[string]string{
+		"user.delete":           "删除用户",
+		"link.delete":           "删除链接",
+		"topic.restore":         "查看和恢复已删除的话题",
+		"topic.force_delete":    "彻底删除话题",
+		"category.restore":      "查看和恢复已删除的分类",
+		"category.force_delete": "彻底删除分类",
+		"user.restore":          "查看和恢复已删除的用户",
+		"user.force_delete":     "彻底删除用户",
+		"link.restore":          "查看和恢复已删除的链接",
+		"link.force_delete":     "彻底删除链接",
+	}
+	// 版主只能查看和恢复话题，其余权限只有管理员拥有
+	moderatorPermissions := []string{"topic.restore"}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 已有的权限数据由 SeedRolesTable 生成，这里为已初始化的数据库补充删除和回收站的权限
+		now := time.Now()
+		for name, description := range permissions {
+			_, err := DB.Exec("INSERT INTO permissions (name, description, created_at, updated_at) "+
+				"SELECT ?, ?, ?, ? FROM roles WHERE name = ? AND NOT EXISTS (SELECT 1 FROM permissions WHERE name = ?)",
+				name, description, now, now, "moderator", name)
+			console.ExitIf(err)
+		}
+		for _, name := range moderatorPermissions {
+			_, err := DB.Exec("INSERT INTO role_permissions (role_id, permission_id) "+
+				"SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = ? AND permissions.name = ? "+
+				"AND NOT EXISTS (SELECT 1 FROM role_permissions WHERE role_id = roles.id AND permission_id = permissions.id)",
+				"moderator", name)
+			console.ExitIf(err)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for name := range permissions {
+			_, err := DB.Exec("DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE name = ?)", name)
+			console.ExitIf(err)
+			_, err = DB.Exec("DELETE FROM permissions WHERE name = ?", name)
+			console.ExitIf(err)
+		}
+	}
+
+	migrate.Add("2026_10_20_160000_add_trash_permissions", up, down)
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/pkg/console"
+	"gohub/pkg/migrate"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	// 编辑和删除任意回复的权限
+	permissions := map[string]string{
+		"reply.update": "编辑任意回复",
+		"reply.delete": "删除任意回复",
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		// 已有的权限数据由 SeedRolesTable 生成，这里为已初始化的数据库补充回复的权限，并授予版主
+		now := time.Now()
+		for name, description := range permissions {
+			_, err := DB.Exec("INSERT INTO permissions (name, description, created_at, updated_at) "+
+				"SELECT ?, ?, ?, ? FROM roles WHERE name = ? AND NOT EXISTS (SELECT 1 FROM permissions WHERE name = ?)",
+				name, description, now, now, "moderator", name)
+			console.ExitIf(err)
+			_, err = DB.Exec("INSERT INTO role_permissions (role_id, permission_id) "+
+				"SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = ? AND permissions.name = ? "+
+				"AND NOT EXISTS (SELECT 1 FROM role_permissions WHERE role_id = roles.id AND permission_id = permissions.id)",
+				"moderator", name)
+			console.ExitIf(err)
+		}
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		for name := range permissions {
+			_, err := DB.Exec("DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE name = ?)", name)
+			console.ExitIf(err)
+			_, err = DB.Exec("DELETE FROM permissions WHERE name = ?", name)
+			console.ExitIf(err)
+		}
+	}
+
+	migrate.Add("2026_10_20_160100_add_reply_moderation_permissions", up, down)
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+	"gohub/app/models"
+	"gohub/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	type Report struct {
+		models.BaseModel
+		Reopened bool `gorm:"not null;default:false"`
+	}
+
+	up := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.AutoMigrate(&Report{})
+	}
+
+	down := func(migrator gorm.Migrator, DB *sql.DB) {
+		migrator.DropColumn(&Report{}, "Reopened")
+	}
+
+	migrate.Add("2026_10_20_160200_add_reopened_to_reports_table", up, down)
+}
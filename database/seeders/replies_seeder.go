@@ -0,0 +1,32 @@
+package seeders
+
+import (
+	"fmt"
+	"gohub/database/factories"
+	"gohub/pkg/console"
+	"gohub/pkg/logger"
+	"gohub/pkg/seed"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	seed.Add("SeedRepliesTable", func(db *gorm.DB) {
+
+		replies := factories.MakeReplies(30)
+
+		// 逐条创建，以便 AfterCreate 钩子更新话题的回复数
+		var rowsAffected int64
+		for i := range replies {
+			result := db.Create(&replies[i])
+			if err := result.Error; err != nil {
+				logger.LogIf(err)
+				return
+			}
+			rowsAffected += result.RowsAffected
+		}
+
+		console.Success(fmt.Sprintf("Table [%v] %v rows seeded", "replies", rowsAffected))
+	})
+}
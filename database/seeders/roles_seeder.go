@@ -0,0 +1,70 @@
+package seeders
+
+import (
+	"fmt"
+	"gohub/app/models/permission"
+	"gohub/app/models/role"
+	"gohub/pkg/console"
+	"gohub/pkg/logger"
+	"gohub/pkg/seed"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+
+	seed.Add("SeedRolesTable", func(db *gorm.DB) {
+
+		// 系统内置的权限
+		permissions := []permission.Permission{
+			{Name: "category.create", Description: "创建分类"},
+			{Name: "category.update", Description: "编辑分类"},
+			{Name: "category.delete", Description: "删除分类"},
+			{Name: "category.restore", Description: "查看和恢复已删除的分类"},
+			{Name: "category.force_delete", Description: "彻底删除分类"},
+			{Name: "user.delete", Description: "删除用户"},
+			{Name: "user.restore", Description: "查看和恢复已删除的用户"},
+			{Name: "user.force_delete", Description: "彻底删除用户"},
+			{Name: "link.delete", Description: "删除链接"},
+			{Name: "link.restore", Description: "查看和恢复已删除的链接"},
+			{Name: "link.force_delete", Description: "彻底删除链接"},
+			{Name: "topic.update", Description: "编辑任意话题"},
+			{Name: "topic.delete", Description: "删除任意话题"},
+			{Name: "topic.restore", Description: "查看和恢复已删除的话题"},
+			{Name: "topic.force_delete", Description: "彻底删除话题"},
+			{Name: "reply.update", Description: "编辑任意回复"},
+			{Name: "reply.delete", Description: "删除任意回复"},
+			{Name: "report.moderate", Description: "处理举报"},
+			{Name: "topic.pin", Description: "置顶话题"},
+			{Name: "topic.lock", Description: "锁定话题"},
+		}
+		if err := db.Create(&permissions).Error; err != nil {
+			logger.LogIf(err)
+			return
+		}
+
+		// 管理员拥有所有权限，无需关联；版主可以管理所有话题和回复、处理举报、置顶和锁定话题，但不能彻底删除
+		var moderatorPermissions []permission.Permission
+		for _, p := range permissions {
+			switch p.Name {
+			case "topic.update", "topic.delete", "topic.restore", "reply.update", "reply.delete", "report.moderate",
+				"topic.pin", "topic.lock":
+				moderatorPermissions = append(moderatorPermissions, p)
+			}
+		}
+		roles := []role.Role{
+			{Name: role.Admin, Description: "管理员"},
+			{Name: "moderator", Description: "版主", Permissions: moderatorPermissions},
+		}
+		result := db.Create(&roles)
+		if err := result.Error; err != nil {
+			logger.LogIf(err)
+			return
+		}
+
+		// 第一个用户设置为管理员
+		roles[0].AssignTo("1")
+
+		console.Success(fmt.Sprintf("Table [%v] %v rows seeded", result.Statement.Table, result.RowsAffected))
+	})
+}
@@ -8,5 +8,8 @@ func Initialize() {
 	// 指定优先于同目录下的其他文件运行
 	seed.SetRunOrder([]string{
 		"SeedUsersTable",
+		"SeedCategoriesTable",
+		"SeedTopicsTable",
+		"SeedRepliesTable",
 	})
 }
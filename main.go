@@ -48,11 +48,14 @@ func main() {
 	rootCmd.AddCommand(
 		cmd.CmdServe,
 		cmd.CmdKey,
+		cmd.CmdJWTKey,
 		cmd.CmdPlay,
 		make.CmdMake,
 		cmd.CmdMigrate,
 		cmd.CmdDBSeed,
 		cmd.CmdCache,
+		cmd.CmdRole,
+		cmd.CmdSearchReindex,
 	)
 
 	// 配置默认运行 Web 服务
@@ -23,11 +23,32 @@ func TimenowInTimezone() time.Time {
 	return time.Now().In(chinaTimezone)
 }
 
+// ParseDateTime 解析 2006-01-02 15:04:05 格式的时间，使用 app.timezone 时区，同时支持 RFC3339 格式
+func ParseDateTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05", value, TimenowInTimezone().Location())
+}
+
 // URL 传参 path 拼接站点的 URL
 func URL(path string) string {
 	return config.Get("app.url") + path
 }
 
+// V1Prefix API v1 路由的前缀，未设置 api 域名时为 /api/v1，否则为 /v1
+func V1Prefix() string {
+	if len(config.Get("app.api_domain")) == 0 {
+		return "/api/v1"
+	}
+	return "/v1"
+}
+
+// V1RouteURL 拼接 API v1 路由的完整 URL，前缀与路由注册时一致，用于邮件等站外链接
+func V1RouteURL(path string) string {
+	return URL(V1Prefix() + "/" + path)
+}
+
 // V1URL 拼接带 v1 标示 URL
 func V1URL(path string) string {
 	return URL("/v1/" + path)
@@ -2,8 +2,12 @@ package auth
 
 import (
 	"errors"
+	"gohub/app/models/permission"
+	"gohub/app/models/role"
 	"gohub/app/models/user"
+	"gohub/pkg/helpers"
 	"gohub/pkg/logger"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +24,21 @@ func Attempt(loginID, password string) (user.User, error) {
 	return userModel, nil
 }
 
+// LockoutKey 登录失败计数使用的 key，同一账号使用手机号、Email 或用户名登录时共用计数
+// 账号不存在时按登录 ID 计数，避免通过响应差异判断账号是否存在
+func LockoutKey(loginID string) string {
+	userModel := user.GetByMulti(loginID)
+	if userModel.ID == 0 {
+		return "login:" + strings.ToLower(strings.TrimSpace(loginID))
+	}
+	return UserLockoutKey(userModel)
+}
+
+// UserLockoutKey 已知用户时的登录失败计数 key
+func UserLockoutKey(userModel user.User) string {
+	return "user:" + userModel.GetStringID()
+}
+
 // LoginByPhone 登录指定用户
 func LoginByPhone(phone string) (user.User, error) {
 	userModel := user.GetByPhone(phone)
@@ -44,3 +63,71 @@ func CurrentUser(c *gin.Context) user.User {
 func CurrentUID(c *gin.Context) string {
 	return c.GetString("current_user_id")
 }
+
+// CurrentSessionID 从 gin.context 中获取当前登录会话 ID
+func CurrentSessionID(c *gin.Context) string {
+	return c.GetString("current_session_id")
+}
+
+// CurrentTokenID 从 gin.context 中获取当前使用的个人访问令牌 ID，使用 JWT 登录时为空
+func CurrentTokenID(c *gin.Context) string {
+	return c.GetString("current_token_id")
+}
+
+// IsPersonalAccessToken 当前请求是否使用个人访问令牌认证
+func IsPersonalAccessToken(c *gin.Context) bool {
+	return len(CurrentTokenID(c)) > 0
+}
+
+// TokenCan 当前请求的令牌是否拥有某个作用域，使用 JWT 登录时拥有所有作用域
+func TokenCan(c *gin.Context, scope string) bool {
+	if !IsPersonalAccessToken(c) {
+		return true
+	}
+	return helpers.InSlice(scope, c.GetStringSlice("current_token_scopes"))
+}
+
+// Can 判断当前登录用户是否拥有某个权限，管理员拥有所有权限，调用示例：
+//         if !auth.Can(c, "category.delete") { ... }
+func Can(c *gin.Context, permissionName string) bool {
+	if IsAdmin(c) {
+		return true
+	}
+	return helpers.InSlice(permissionName, currentPermissions(c))
+}
+
+// HasRole 判断当前登录用户是否拥有某个角色
+func HasRole(c *gin.Context, roleName string) bool {
+	return helpers.InSlice(roleName, currentRoles(c))
+}
+
+// IsAdmin 判断当前登录用户是否为管理员
+func IsAdmin(c *gin.Context) bool {
+	return HasRole(c, role.Admin)
+}
+
+// currentRoles 当前用户的角色，同一个请求内只查询一次数据库
+func currentRoles(c *gin.Context) []string {
+	if roles, ok := c.Get("current_user_roles"); ok {
+		return roles.([]string)
+	}
+	roles := []string{}
+	if uid := CurrentUID(c); len(uid) > 0 {
+		roles = role.NamesByUser(uid)
+	}
+	c.Set("current_user_roles", roles)
+	return roles
+}
+
+// currentPermissions 当前用户的权限，同一个请求内只查询一次数据库
+func currentPermissions(c *gin.Context) []string {
+	if permissions, ok := c.Get("current_user_permissions"); ok {
+		return permissions.([]string)
+	}
+	permissions := []string{}
+	if uid := CurrentUID(c); len(uid) > 0 {
+		permissions = permission.NamesByUser(uid)
+	}
+	c.Set("current_user_permissions", permissions)
+	return permissions
+}
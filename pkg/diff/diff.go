@@ -0,0 +1,115 @@
+// Package diff 文本的逐行对比
+package diff
+
+import "strings"
+
+// 行的变化类型
+const (
+	OpEqual  = "equal"  // 两个版本中都有
+	OpInsert = "insert" // 新版本中增加的行
+	OpDelete = "delete" // 旧版本中删除的行
+)
+
+// maxCells 最长公共子序列的计算表格上限，超过时不再逐行对齐，整体作为删除和新增
+const maxCells = 4000000
+
+// Line 对比结果中的一行
+type Line struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Lines 逐行对比 a 和 b，返回由 a 变为 b 的行序列，未变化的行 Op 为 OpEqual
+// 基于最长公共子序列，同一处修改中删除的行排在新增的行之前
+func Lines(a, b string) []Line {
+	oldLines, newLines := split(a), split(b)
+
+	// 去掉相同的开头和结尾，只对中间变化的部分计算
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]Line, 0, len(oldLines)+len(newLines))
+	lines = appendLines(lines, OpEqual, oldLines[:prefix])
+	lines = append(lines, compare(oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])...)
+	lines = appendLines(lines, OpEqual, oldLines[len(oldLines)-suffix:])
+	return lines
+}
+
+// Stats 统计新增和删除的行数
+func Stats(lines []Line) (insertions, deletions int) {
+	for _, line := range lines {
+		switch line.Op {
+		case OpInsert:
+			insertions++
+		case OpDelete:
+			deletions++
+		}
+	}
+	return
+}
+
+// compare 使用最长公共子序列对齐 a 和 b
+func compare(a, b []string) []Line {
+	var lines []Line
+	if len(a)*len(b) > maxCells {
+		lines = appendLines(lines, OpDelete, a)
+		return appendLines(lines, OpInsert, b)
+	}
+
+	// lcs[i][j] 为 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Op: OpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	lines = appendLines(lines, OpDelete, a[i:])
+	return appendLines(lines, OpInsert, b[j:])
+}
+
+func appendLines(lines []Line, op string, texts []string) []Line {
+	for _, text := range texts {
+		lines = append(lines, Line{Op: op, Text: text})
+	}
+	return lines
+}
+
+// split 按行拆分，统一换行符，空文本没有任何行
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
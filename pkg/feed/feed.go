@@ -0,0 +1,140 @@
+// Package feed 用户动态时间线，基于 Redis 有序集合
+//
+// 每个用户有一个发件箱（自己发布的动态）和一个收件箱（关注的人发布的动态）。
+// 发布动态时写入发件箱，并写入所有粉丝的收件箱（推模式，fan-out-on-write）；
+// 粉丝数很多的用户只写入发件箱，粉丝读取时间线时再合并其发件箱（拉模式，fan-out-on-read）。
+// 选择推或拉、以及获取粉丝列表由调用方决定，见 follow.PublishActivity
+package feed
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/redis"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 动态类型
+const (
+	VerbTopicCreated = "topic.created" // 发布话题
+	VerbReplyCreated = "reply.created" // 回复话题
+)
+
+// Activity 一条动态，只保存 ID，读取时间线时再从数据库读取内容，已删除的内容随之不再显示
+type Activity struct {
+	Verb     string `json:"verb"`
+	ActorID  string `json:"actor_id"`
+	ObjectID string `json:"object_id"` // 话题或回复的 ID
+	TopicID  string `json:"topic_id"`
+	Time     int64  `json:"time"` // 微秒时间戳，同时用作有序集合的 score 和分页游标
+}
+
+// NewActivity 创建一条当前时间的动态
+func NewActivity(verb, actorID, objectID, topicID string) Activity {
+	return Activity{
+		Verb:     verb,
+		ActorID:  actorID,
+		ObjectID: objectID,
+		TopicID:  topicID,
+		Time:     time.Now().UnixMicro(),
+	}
+}
+
+// Feed 动态时间线
+type Feed struct {
+	Store      Store
+	InboxSize  int
+	OutboxSize int
+}
+
+// once 确保 internalFeed 对象只初始化一次
+var once sync.Once
+
+// internalFeed 内部使用的 Feed 对象
+var internalFeed *Feed
+
+// NewFeed 单例模式获取
+func NewFeed() *Feed {
+	once.Do(func() {
+		internalFeed = &Feed{
+			Store: &RedisStore{
+				RedisClient: redis.Redis,
+				KeyPrefix:   config.GetString("app.name") + ":feed:",
+			},
+			InboxSize:  config.GetInt("feed.inbox_size"),
+			OutboxSize: config.GetInt("feed.outbox_size"),
+		}
+	})
+	return internalFeed
+}
+
+// Publish 发布动态，写入作者的发件箱和 followerIDs 的收件箱，拉模式下 followerIDs 传参 nil
+func (f *Feed) Publish(activity Activity, followerIDs []string) error {
+	if err := f.Store.Add([]string{outboxKey(activity.ActorID)}, activity, f.OutboxSize); err != nil {
+		return err
+	}
+	if len(followerIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(followerIDs))
+	for _, id := range followerIDs {
+		keys = append(keys, inboxKey(id))
+	}
+	return f.Store.Add(keys, activity, f.InboxSize)
+}
+
+// Backfill 将 actorID 最近的 limit 条动态写入 userID 的收件箱，关注用户时调用
+func (f *Feed) Backfill(userID, actorID string, limit int) error {
+	activities, err := f.Store.Range(outboxKey(actorID), 0, limit)
+	if err != nil {
+		return err
+	}
+	for _, activity := range activities {
+		if err := f.Store.Add([]string{inboxKey(userID)}, activity, f.InboxSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Timeline 读取 userID 的时间线，合并收件箱和 pullActorIDs（拉模式的用户）的发件箱，按时间倒序
+// before 为上一页最后一条动态的 Time，首页传参 0；next 为下一页的 before，没有更多数据时为 0
+func (f *Feed) Timeline(userID string, pullActorIDs []string, before int64, limit int) (activities []Activity, next int64, err error) {
+	keys := []string{inboxKey(userID)}
+	for _, id := range pullActorIDs {
+		keys = append(keys, outboxKey(id))
+	}
+
+	// 每个集合都读取 limit+1 条，合并后取前 limit 条，多出的一条用以判断是否有下一页
+	seen := make(map[Activity]bool)
+	for _, key := range keys {
+		items, err := f.Store.Range(key, before, limit+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, item := range items {
+			// 关注时回填的动态，可能同时在收件箱和发件箱中
+			if !seen[item] {
+				seen[item] = true
+				activities = append(activities, item)
+			}
+		}
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Time > activities[j].Time
+	})
+
+	if len(activities) > limit {
+		activities = activities[:limit]
+		next = activities[limit-1].Time
+	}
+	return activities, next, nil
+}
+
+func inboxKey(userID string) string {
+	return "inbox:" + userID
+}
+
+func outboxKey(userID string) string {
+	return "outbox:" + userID
+}
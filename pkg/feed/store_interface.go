@@ -0,0 +1,9 @@
+package feed
+
+type Store interface {
+	// Add 将动态写入多个有序集合，每个集合最多保留 maxLen 条最新的动态
+	Add(keys []string, activity Activity, maxLen int) error
+
+	// Range 按时间倒序读取 key 中早于 before（微秒时间戳，0 表示不限）的动态，最多 limit 条
+	Range(key string, before int64, limit int) ([]Activity, error)
+}
@@ -0,0 +1,67 @@
+package feed
+
+import (
+	"encoding/json"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"strconv"
+
+	redislib "github.com/go-redis/redis/v8"
+)
+
+// RedisStore 实现 feed.Store interface
+// 每个收件箱和发件箱为一个有序集合，member 为动态的 JSON，score 为动态的微秒时间戳
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Add 实现 feed.Store interface 的 Add 方法，推模式下一次写入大量粉丝的收件箱，使用 pipeline 批量执行
+func (s *RedisStore) Add(keys []string, activity Activity, maxLen int) error {
+	member, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+	ctx := s.RedisClient.Context
+	_, err = s.RedisClient.Client.Pipelined(ctx, func(pipe redislib.Pipeliner) error {
+		for _, key := range keys {
+			pipe.ZAdd(ctx, s.KeyPrefix+key, &redislib.Z{
+				Score:  float64(activity.Time),
+				Member: string(member),
+			})
+			// 只保留最新的 maxLen 条
+			pipe.ZRemRangeByRank(ctx, s.KeyPrefix+key, 0, int64(-maxLen-1))
+		}
+		return nil
+	})
+	return err
+}
+
+// Range 实现 feed.Store interface 的 Range 方法
+func (s *RedisStore) Range(key string, before int64, limit int) ([]Activity, error) {
+	max := "+inf"
+	if before > 0 {
+		max = "(" + strconv.FormatInt(before, 10)
+	}
+	members, err := s.RedisClient.Client.ZRevRangeByScore(s.RedisClient.Context, s.KeyPrefix+key, &redislib.ZRangeBy{
+		Min:   "-inf",
+		Max:   max,
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]Activity, 0, len(members))
+	for _, member := range members {
+		var activity Activity
+		if err := json.Unmarshal([]byte(member), &activity); err != nil {
+			logger.LogIf(err)
+			continue
+		}
+		activities = append(activities, activity)
+	}
+	return activities, nil
+}
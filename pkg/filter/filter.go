@@ -0,0 +1,119 @@
+// Package filter 敏感词过滤和垃圾内容识别，词表支持从文件或数据库加载，运行中可重新加载
+package filter
+
+import (
+	"context"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"sync"
+	"time"
+)
+
+// 命中敏感词后的处理方式
+const (
+	ModeReject = "reject" // 拒绝提交，见 no_sensitive_words 验证规则
+	ModeMask   = "mask"   // 保存时替换为 *
+	ModeReview = "review" // 允许发布，进入举报处理队列由版主审核
+)
+
+// Filter 敏感词过滤服务
+type Filter struct {
+	Source Source
+
+	mu      sync.RWMutex
+	matcher *Matcher
+}
+
+// once 确保 internalFilter 对象只初始化一次
+var once sync.Once
+
+// internalFilter 内部使用的 Filter 对象
+var internalFilter *Filter
+
+// NewFilter 单例模式获取，首次调用时加载词表，加载失败只记录日志，词表为空
+func NewFilter() *Filter {
+	once.Do(func() {
+		defaultMode := config.GetString("filter.default_mode", ModeReject)
+		internalFilter = &Filter{}
+		switch config.GetString("filter.source") {
+		case "database":
+			internalFilter.Source = &Database{Table: config.GetString("filter.table"), DefaultMode: defaultMode}
+		default:
+			internalFilter.Source = &File{Path: config.GetString("filter.file"), DefaultMode: defaultMode}
+		}
+		logger.LogIf(internalFilter.Reload())
+	})
+	return internalFilter
+}
+
+// Reload 重新加载词表，加载失败时继续使用原词表
+func (f *Filter) Reload() error {
+	words, err := f.Source.Load()
+	if err != nil {
+		return err
+	}
+	matcher := NewMatcher(words)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.matcher = matcher
+	return nil
+}
+
+// Run 按 filter.reload_interval 配置定时重新加载词表，修改词表后无需重启服务，ctx 取消时退出
+func (f *Filter) Run(ctx context.Context) {
+	interval := config.GetInt("filter.reload_interval")
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logger.LogIf(f.Reload())
+		}
+	}
+}
+
+// Check 找出文本中所有的敏感词
+func (f *Filter) Check(text string) Result {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return Result{Matches: f.matcher.Find(text)}
+}
+
+// Mask 将 mask 模式的敏感词替换为 *，在模型保存前调用
+func (f *Filter) Mask(text string) string {
+	var matches []Match
+	for _, match := range f.Check(text).Matches {
+		if match.Word.Mode == ModeMask {
+			matches = append(matches, match)
+		}
+	}
+	return mask(text, matches)
+}
+
+// Result 检查结果
+type Result struct {
+	Matches []Match
+}
+
+// Words 命中的 mode 模式的敏感词，已去重
+func (r Result) Words(mode string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, match := range r.Matches {
+		if match.Word.Mode == mode && !seen[match.Word.Text] {
+			seen[match.Word.Text] = true
+			words = append(words, match.Word.Text)
+		}
+	}
+	return words
+}
+
+func isMode(mode string) bool {
+	return mode == ModeReject || mode == ModeMask || mode == ModeReview
+}
@@ -0,0 +1,194 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word 词表中的词
+type Word struct {
+	Text string
+	// 命中后的处理方式，ModeReject、ModeMask 或 ModeReview
+	Mode string
+}
+
+// Match 一次命中，Start 和 End 为原文中的字节位置
+type Match struct {
+	Word  Word
+	Start int
+	End   int
+}
+
+// Matcher Aho-Corasick 自动机，一次扫描找出文本中所有词表中的词，耗时与词表大小无关
+// 匹配前统一转小写、全角转半角，并忽略空白和标点，如 "加 微-信" 也能匹配 "加微信"
+// 英文和数字开头或结尾的词需要完整的单词才算命中，避免 "he" 命中 "ushers"
+type Matcher struct {
+	nodes []node
+	words []Word
+	// 每个词规范化后的字符数
+	lengths []int
+}
+
+type node struct {
+	next map[rune]int
+	// 失配时跳转的节点，即当前路径最长的、同时是某个词前缀的后缀
+	fail int
+	// 以该节点结尾的词，包含 fail 链上的词，值为 words 的下标
+	outputs []int
+	depth   int
+}
+
+// NewMatcher 由词表构建自动机，规范化后为空的词忽略
+func NewMatcher(words []Word) *Matcher {
+	m := &Matcher{nodes: []node{{next: map[rune]int{}}}}
+	for _, word := range words {
+		current, length := 0, 0
+		for _, r := range word.Text {
+			r, ok := normalizeRune(r)
+			if !ok {
+				continue
+			}
+			length++
+			next, exists := m.nodes[current].next[r]
+			if !exists {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, node{next: map[rune]int{}, depth: m.nodes[current].depth + 1})
+				m.nodes[current].next[r] = next
+			}
+			current = next
+		}
+		if current == 0 {
+			continue
+		}
+		m.nodes[current].outputs = append(m.nodes[current].outputs, len(m.words))
+		m.words = append(m.words, word)
+		m.lengths = append(m.lengths, length)
+	}
+	m.buildFailLinks()
+	return m
+}
+
+// buildFailLinks 按层（广度优先）计算失配跳转，子节点的 fail 由父节点的 fail 推出
+func (m *Matcher) buildFailLinks() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[current].next {
+			fail := m.nodes[current].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[m.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Find 找出文本中所有命中的词，同一位置命中多个词时都返回
+func (m *Matcher) Find(text string) []Match {
+	var matches []Match
+	if m == nil || len(m.words) == 0 {
+		return matches
+	}
+
+	// 参与匹配的字符在原文中的起始位置，用于将命中位置还原到原文
+	var positions []int
+	current := 0
+	for i, r := range text {
+		r, ok := normalizeRune(r)
+		if !ok {
+			continue
+		}
+		positions = append(positions, i)
+
+		for current > 0 {
+			if _, ok := m.nodes[current].next[r]; ok {
+				break
+			}
+			current = m.nodes[current].fail
+		}
+		if next, ok := m.nodes[current].next[r]; ok {
+			current = next
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		for _, index := range m.nodes[current].outputs {
+			match := Match{
+				Word:  m.words[index],
+				Start: positions[len(positions)-m.lengths[index]],
+				End:   i + size,
+			}
+			if isWholeWord(text, match) {
+				matches = append(matches, match)
+			}
+		}
+	}
+	return matches
+}
+
+// isWholeWord 命中的首尾是英文或数字时，前后不能紧接着英文或数字
+func isWholeWord(text string, match Match) bool {
+	first, _ := utf8.DecodeRuneInString(text[match.Start:])
+	if before, _ := utf8.DecodeLastRuneInString(text[:match.Start]); isAlnum(first) && isAlnum(before) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(text[:match.End])
+	if after, _ := utf8.DecodeRuneInString(text[match.End:]); isAlnum(last) && isAlnum(after) {
+		return false
+	}
+	return true
+}
+
+// isAlnum 是否为英文字母或数字，包括全角
+func isAlnum(r rune) bool {
+	r, ok := normalizeRune(r)
+	return ok && r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// normalizeRune 全角转半角、转小写，空白、标点和符号返回 false，不参与匹配
+func normalizeRune(r rune) (rune, bool) {
+	switch {
+	case r == '　':
+		return r, false
+	case r >= '！' && r <= '～':
+		r -= 0xFEE0
+	}
+	if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+		return r, false
+	}
+	return unicode.ToLower(r), true
+}
+
+// mask 将命中的位置替换为 *，每个字符替换为一个 *
+func mask(text string, matches []Match) string {
+	if len(matches) == 0 {
+		return text
+	}
+	masked := make([]bool, len(text))
+	for _, match := range matches {
+		for i := match.Start; i < match.End; i++ {
+			masked[i] = true
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range text {
+		if masked[i] {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package filter
+
+import "gohub/pkg/database"
+
+// Database 从数据表读取词表，表结构见 sensitive_words 迁移，mode 为空时使用 DefaultMode
+type Database struct {
+	Table       string
+	DefaultMode string
+}
+
+var _ Source = (*Database)(nil)
+
+func (d *Database) Load() ([]Word, error) {
+	var rows []struct {
+		Word string
+		Mode string
+	}
+	if err := database.DB.Table(d.Table).Select("word, mode").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	words := make([]Word, 0, len(rows))
+	for _, row := range rows {
+		mode := row.Mode
+		if !isMode(mode) {
+			mode = d.DefaultMode
+		}
+		words = append(words, Word{Text: row.Word, Mode: mode})
+	}
+	return words, nil
+}
@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// File 从文本文件读取词表，每行一个词，格式为 词 或 词,处理方式，如：
+//         加微信,review
+//         代开发票
+// 未指定处理方式时使用 filter.default_mode 配置，# 开头的行为注释，文件不存在时词表为空
+type File struct {
+	Path        string
+	DefaultMode string
+}
+
+var _ Source = (*File)(nil)
+
+func (f *File) Load() ([]Word, error) {
+	file, err := os.Open(f.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []Word
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word := Word{Text: line, Mode: f.DefaultMode}
+		if i := strings.LastIndexByte(line, ','); i >= 0 && isMode(strings.TrimSpace(line[i+1:])) {
+			word = Word{Text: strings.TrimSpace(line[:i]), Mode: strings.TrimSpace(line[i+1:])}
+		}
+		words = append(words, word)
+	}
+	return words, scanner.Err()
+}
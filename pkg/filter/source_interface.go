@@ -0,0 +1,6 @@
+package filter
+
+type Source interface {
+	// Load 读取完整的词表，重新加载时同样读取完整的词表
+	Load() ([]Word, error)
+}
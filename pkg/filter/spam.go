@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"gohub/pkg/cache"
+	"gohub/pkg/config"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// linkRegex 链接，包括 Markdown 链接中的地址和裸链接
+var linkRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>()\[\]"']+`)
+
+// LinkCount 文本中的链接数，超过 filter.spam.max_links 配置时视为垃圾内容
+func LinkCount(text string) int {
+	return len(linkRegex.FindAllStringIndex(text, -1))
+}
+
+// TooManyLinks 链接数是否超过 filter.spam.max_links 配置，配置为 0 时不限制
+func TooManyLinks(text string) bool {
+	maxLinks := config.GetInt("filter.spam.max_links")
+	return maxLinks > 0 && LinkCount(text) > maxLinks
+}
+
+// IsDuplicate 用户在 filter.spam.duplicate_window 秒内是否发布过相同的内容，
+// 比较时忽略大小写、空白和标点，发布成功后需调用 RememberPost 记录
+func IsDuplicate(userID, content string) bool {
+	if config.GetInt("filter.spam.duplicate_window") <= 0 {
+		return false
+	}
+	return cache.Has(postKey(userID, content))
+}
+
+// RememberPost 记录用户发布的内容，用于 IsDuplicate 判断重复发布
+func RememberPost(userID, content string) {
+	window := config.GetInt("filter.spam.duplicate_window")
+	if window <= 0 {
+		return
+	}
+	cache.Set(postKey(userID, content), 1, time.Duration(window)*time.Second)
+}
+
+// postKey 内容指纹的缓存 key，指纹为规范化后内容的 SHA1
+func postKey(userID, content string) string {
+	var b strings.Builder
+	for _, r := range content {
+		if r, ok := normalizeRune(r); ok {
+			b.WriteRune(r)
+		}
+	}
+	sum := sha1.Sum([]byte(b.String()))
+	return "spam:post:" + userID + ":" + hex.EncodeToString(sum[:])
+}
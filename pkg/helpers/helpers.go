@@ -62,6 +62,16 @@ func FirstElement(args []string) string {
 	return ""
 }
 
+// InSlice 判断字符串是否在切片中
+func InSlice(needle string, haystack []string) bool {
+	for _, item := range haystack {
+		if item == needle {
+			return true
+		}
+	}
+	return false
+}
+
 // RandomString 生成长度为 length 的随机字符串
 func RandomString(length int) string {
 	mathrand.Seed(time.Now().UnixNano())
@@ -5,11 +5,19 @@ import (
 	"gohub/pkg/app"
 	"gohub/pkg/config"
 	"gohub/pkg/logger"
+	"gohub/pkg/redis"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	jwtpkg "github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// 令牌类型
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
 )
 
 // 自定义错误
@@ -18,14 +26,20 @@ var (
 	ErrTokenExpiredMaxRefresh error = errors.New("令牌已过最大刷新时间")
 	ErrTokenMalformed         error = errors.New("请求令牌格式有误")
 	ErrTokenInvalid           error = errors.New("请求令牌无效")
+	ErrTokenRevoked           error = errors.New("令牌已被吊销")
+	ErrTokenReused            error = errors.New("刷新令牌已被使用，该登录会话已失效")
+	ErrTokenTypeMismatch      error = errors.New("令牌类型有误")
 	ErrHeaderEmpty            error = errors.New("需要认证才能访问")
 	ErrHeaderMalformed        error = errors.New("请求头中 Authorization 格式有误")
+	ErrKeysUnavailable        error = errors.New("JWT 密钥未正确配置")
+	ErrStoreUnavailable       error = errors.New("令牌存储暂时不可用")
 )
 
 // JWT 定义一个jwt对象
 type JWT struct {
-	SignKey    []byte        // 秘钥，用以加密 JWT，读取配置信息 app.key
+	Keys       *KeySet       // 签名和验签密钥，读取配置信息 jwt.algorithm 等
 	MaxRefresh time.Duration // 刷新 Token 的最大过期时间
+	Store      Store         // 令牌黑名单存储
 }
 
 // JWTCustomClaims 自定义载荷
@@ -33,6 +47,8 @@ type JWTCustomClaims struct {
 	UserId       string `json:"user_id"`
 	UserName     string `json:"user_name"`
 	ExpireAtTime int64  `json:"expire_time"`
+	TokenType    string `json:"token_type"` // access 或 refresh
+	FamilyID     string `json:"fid"`        // 令牌家族，同一次登录轮换出的令牌共享此值
 	// StandardClaims 结构体实现了 Claims 接口继承了  Valid() 方法
 	// JWT 规定了7个官方字段，提供使用:
 	// - iss (issuer)：发布者
@@ -41,15 +57,28 @@ type JWTCustomClaims struct {
 	// - exp (expiration time)：签名过期时间
 	// - aud (audience)：观众，相当于接受者
 	// - nbf (Not Before)：生效时间
-	// - jti (JWT ID)：编号
+	// - jti (JWT ID)：编号，用作黑名单的 key
 	jwtpkg.StandardClaims
 }
 
+// TokenPair 登录成功后颁发的令牌对
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	FamilyID     string `json:"-"`
+}
+
 // NewJWT 创建 JWT 实例
 func NewJWT() *JWT {
+	keys, err := LoadKeys()
+	logger.LogIf(err)
 	return &JWT{
-		SignKey:    []byte(config.GetString("app.key")),
+		Keys:       keys,
 		MaxRefresh: time.Duration(config.GetInt64("jwt.max_refresh_time")) * time.Minute,
+		Store: &RedisStore{
+			RedisClient: redis.Redis,
+			KeyPrefix:   config.GetString("app.name") + ":jwt:",
+		},
 	}
 }
 
@@ -76,75 +105,164 @@ func (jwt *JWT) ParserToken(c *gin.Context) (*JWTCustomClaims, error) {
 	}
 
 	// 3. 将 token 中的 claims 信息解析出来和 JWTCustomClaims 数据结构进行校验
-	if claims, ok := token.Claims.(*JWTCustomClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*JWTCustomClaims)
+	if !ok || !token.Valid {
+		return nil, ErrTokenInvalid
+	}
+
+	// 4. 刷新令牌不能用来访问接口
+	if claims.TokenType != TokenTypeAccess {
+		return nil, ErrTokenTypeMismatch
+	}
+
+	// 5. 检查黑名单
+	if jwt.isRevoked(claims) {
+		return nil, ErrTokenRevoked
 	}
-	return nil, ErrTokenInvalid
+	return claims, nil
 }
 
-// RefreshToken 更新 Token，用以提供 refresh token 接口
-func (jwt *JWT) RefreshToken(c *gin.Context) (string, error) {
-	// 1. 从 Header 里获取 token
+// RefreshToken 使用刷新令牌换取新的令牌对，用以提供 refresh token 接口
+// 刷新令牌只能使用一次，重复使用会吊销整个令牌家族
+func (jwt *JWT) RefreshToken(c *gin.Context) (TokenPair, error) {
+	// 1. 从 Header 里获取 refresh token
 	tokenString, parseErr := jwt.getTokenFromHeader(c)
 	if parseErr != nil {
-		return "", parseErr
+		return TokenPair{}, parseErr
 	}
 
 	// 2. 调用 jwt 库解析用户传参的 Token
 	token, err := jwt.parseTokenString(tokenString)
-
-	// 3. 解析出错，未报错证明是合法的 Token（甚至未到过期时间）
 	if err != nil {
 		validationErr, ok := err.(*jwtpkg.ValidationError)
-		// 满足 refresh 的条件：只是单一的报错 ValidationErrorExpired
-		if !ok || validationErr.Errors != jwtpkg.ValidationErrorExpired {
-			return "", err
+		// 刷新令牌过期，即超过了『最大允许刷新的时间』
+		if ok && validationErr.Errors == jwtpkg.ValidationErrorExpired {
+			return TokenPair{}, ErrTokenExpiredMaxRefresh
 		}
+		return TokenPair{}, ErrTokenInvalid
+	}
+
+	// 3. 解析 JWTCustomClaims 的数据
+	claims, ok := token.Claims.(*JWTCustomClaims)
+	if !ok || !token.Valid {
+		return TokenPair{}, ErrTokenInvalid
+	}
+	if claims.TokenType != TokenTypeRefresh {
+		return TokenPair{}, ErrTokenTypeMismatch
+	}
+	if jwt.Store.IsFamilyRevoked(claims.FamilyID) {
+		return TokenPair{}, ErrTokenRevoked
 	}
 
-	// 4. 解析 JWTCustomClaims 的数据
-	claims := token.Claims.(*JWTCustomClaims)
+	// 4. 将当前刷新令牌加入黑名单，已在黑名单中说明被重复使用，可能已泄露，吊销整个家族
+	// 存储出错时无法判断是否重复使用，不吊销家族，由调用方返回服务器错误
+	ok, err = jwt.Store.Revoke(claims.Id, jwt.ttl(claims.ExpiresAt))
+	if err != nil {
+		return TokenPair{}, ErrStoreUnavailable
+	}
+	if !ok {
+		jwt.RevokeFamily(claims.FamilyID)
+		return TokenPair{}, ErrTokenReused
+	}
+
+	// 5. 轮换出新的令牌对，签发时间沿用首次登录时间
+	return jwt.issueTokenPair(claims.UserId, claims.UserName, claims.FamilyID, claims.IssuedAt)
+}
 
-	// 5. 检查是否过了『最大允许刷新的时间』
-	x := app.TimenowInTimezone().Add(-jwt.MaxRefresh).Unix()
-	if claims.ExpiresAt > x {
-		// 修改过期时间
-		claims.StandardClaims.ExpiresAt = jwt.expireAtTime()
-		return jwt.createToken(*claims)
+// IssueToken 生成令牌对，在登录成功时调用
+func (jwt *JWT) IssueToken(userId, userName string) TokenPair {
+	pair, err := jwt.issueTokenPair(userId, userName, uuid.New().String(), app.TimenowInTimezone().Unix())
+	if err != nil {
+		logger.LogIf(err)
+		return TokenPair{}
 	}
-	return "", ErrTokenExpiredMaxRefresh
+	return pair
+}
+
+// RevokeToken 将单个令牌加入黑名单，直到其自然过期，令牌已在黑名单中时同样返回 true
+func (jwt *JWT) RevokeToken(claims *JWTCustomClaims) bool {
+	_, err := jwt.Store.Revoke(claims.Id, jwt.ttl(claims.ExpiresAt))
+	return err == nil
+}
+
+// RevokeFamily 吊销同一次登录派生出的所有令牌
+func (jwt *JWT) RevokeFamily(familyID string) bool {
+	return jwt.Store.RevokeFamily(familyID, jwt.MaxRefresh)
 }
 
-// IssueToken 生成  Token，在登录成功时调用
-func (jwt *JWT) IssueToken(userId, userName string) string {
-	// 1. 构造用户 claims 信息(负荷)
+// issueTokenPair 签发 access token 和 refresh token
+// issuedAt 为首次登录的时间，refresh token 在 issuedAt + MaxRefresh 后失效
+func (jwt *JWT) issueTokenPair(userId, userName, familyID string, issuedAt int64) (TokenPair, error) {
+	now := app.TimenowInTimezone().Unix()
+
+	// 1. 构造 access token 的 claims 信息(负荷)
 	expireAtTime := jwt.expireAtTime()
-	claims := JWTCustomClaims{
-		userId,
-		userName,
-		expireAtTime,
-		jwtpkg.StandardClaims{
-			NotBefore: app.TimenowInTimezone().Unix(), // 签名生效时间
-			IssuedAt:  app.TimenowInTimezone().Unix(), // 首次签名时间（后续刷新 Token 不会更新）
-			ExpiresAt: expireAtTime,                   // 签名过期时间
-			Issuer:    config.GetString("app.name"),   // 签名颁发者
+	accessClaims := JWTCustomClaims{
+		UserId:       userId,
+		UserName:     userName,
+		ExpireAtTime: expireAtTime,
+		TokenType:    TokenTypeAccess,
+		FamilyID:     familyID,
+		StandardClaims: jwtpkg.StandardClaims{
+			Id:        uuid.New().String(),          // 令牌编号
+			NotBefore: now,                          // 签名生效时间
+			IssuedAt:  issuedAt,                     // 首次签名时间（后续刷新 Token 不会更新）
+			ExpiresAt: expireAtTime,                 // 签名过期时间
+			Issuer:    config.GetString("app.name"), // 签名颁发者
 		},
 	}
 
-	// 2. 根据 claims 生成token对象
-	token, err := jwt.createToken(claims)
+	// 2. refresh token 的有效期从首次签名时间算起
+	refreshExpireAt := time.Unix(issuedAt, 0).Add(jwt.MaxRefresh).Unix()
+	refreshClaims := accessClaims
+	refreshClaims.TokenType = TokenTypeRefresh
+	refreshClaims.ExpireAtTime = refreshExpireAt
+	refreshClaims.StandardClaims.Id = uuid.New().String()
+	refreshClaims.StandardClaims.ExpiresAt = refreshExpireAt
+
+	// 3. 根据 claims 生成 token 对象
+	accessToken, err := jwt.createToken(accessClaims)
 	if err != nil {
-		logger.LogIf(err)
-		return ""
+		return TokenPair{}, err
+	}
+	refreshToken, err := jwt.createToken(refreshClaims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		FamilyID:     familyID,
+	}, nil
+}
+
+// isRevoked 令牌本身或其所属家族在黑名单中
+func (jwt *JWT) isRevoked(claims *JWTCustomClaims) bool {
+	return jwt.Store.IsRevoked(claims.Id) || jwt.Store.IsFamilyRevoked(claims.FamilyID)
+}
+
+// ttl 计算距离过期时间还剩多久，黑名单只需保留到令牌自然过期
+func (jwt *JWT) ttl(expiresAt int64) time.Duration {
+	ttl := time.Until(time.Unix(expiresAt, 0))
+	if ttl <= 0 {
+		ttl = time.Minute
 	}
-	return token
+	return ttl
 }
 
 // createToken 创建 Token，内部使用，外部请调用 IssueToken
 func (jwt *JWT) createToken(claims JWTCustomClaims) (string, error) {
-	// 使用HS256算法进行token生成
-	token := jwtpkg.NewWithClaims(jwtpkg.SigningMethodHS256, claims)
-	return token.SignedString(jwt.SignKey)
+	if jwt.Keys == nil {
+		return "", ErrKeysUnavailable
+	}
+	// 使用配置的算法和当前签名密钥生成 token，非对称密钥在头部带上 kid
+	key := jwt.Keys.Signing
+	token := jwtpkg.NewWithClaims(key.Method, claims)
+	if len(key.ID) > 0 {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.PrivateKey)
 }
 
 // expireAtTime 过期时间
@@ -163,9 +281,10 @@ func (jwt *JWT) expireAtTime() int64 {
 
 // parseTokenString 使用 jwtpkg.ParseWithClaims 解析 Token
 func (jwt *JWT) parseTokenString(tokenString string) (*jwtpkg.Token, error) {
-	return jwtpkg.ParseWithClaims(tokenString, &JWTCustomClaims{}, func(token *jwtpkg.Token) (interface{}, error) {
-		return jwt.SignKey, nil
-	})
+	if jwt.Keys == nil {
+		return nil, ErrKeysUnavailable
+	}
+	return jwtpkg.ParseWithClaims(tokenString, &JWTCustomClaims{}, jwt.Keys.keyFunc)
 }
 
 // getTokenFromHeader 使用 jwtpkg.ParseWithClaims 解析 Token
@@ -0,0 +1,281 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"gohub/pkg/config"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sync"
+
+	jwtpkg "github.com/golang-jwt/jwt"
+)
+
+// Key 签名或验签使用的密钥
+type Key struct {
+	ID         string               // kid，非对称密钥为公钥的 JWK Thumbprint（RFC 7638）
+	Method     jwtpkg.SigningMethod // 签名算法
+	PrivateKey interface{}          // 签名用，仅当前签名密钥有值
+	PublicKey  interface{}          // 验签用，HMAC 时与 PrivateKey 相同
+}
+
+// KeySet 当前签名密钥以及所有可用于验签的密钥
+// 轮换密钥时，旧公钥保留在验签目录中，已签发的令牌在过期前依然有效
+type KeySet struct {
+	Signing      *Key
+	Verification map[string]*Key
+}
+
+var (
+	keysOnce     sync.Once
+	internalKeys *KeySet
+	keysErr      error
+)
+
+// LoadKeys 读取 config/jwt.go 中配置的密钥，只会读取一次
+// 启动 Web 服务前调用，以便尽早发现密钥配置错误
+func LoadKeys() (*KeySet, error) {
+	keysOnce.Do(func() {
+		internalKeys, keysErr = newKeySet()
+	})
+	return internalKeys, keysErr
+}
+
+// newKeySet 根据配置创建 KeySet
+func newKeySet() (*KeySet, error) {
+	ks := &KeySet{Verification: make(map[string]*Key)}
+	algorithm := config.GetString("jwt.algorithm", "HS256")
+
+	method := jwtpkg.GetSigningMethod(algorithm)
+	if method == nil || method == jwtpkg.SigningMethodNone {
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", algorithm)
+	}
+
+	// 1. 对称加密，沿用 app.key 作为秘钥
+	if _, ok := method.(*jwtpkg.SigningMethodHMAC); ok {
+		secret := []byte(config.GetString("app.key"))
+		ks.Signing = &Key{Method: method, PrivateKey: secret, PublicKey: secret}
+		ks.Verification[""] = ks.Signing
+	} else {
+		// 2. 非对称加密，读取 PEM 格式的私钥
+		keyPath := config.GetString("jwt.private_key")
+		pemBytes, err := ioutil.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: read private key %s: %w", keyPath, err)
+		}
+		privateKey, err := parsePrivateKeyPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: parse private key %s: %w", keyPath, err)
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok || !methodMatchesKey(method, signer.Public()) {
+			return nil, fmt.Errorf("jwt: private key %s does not match algorithm %s", keyPath, algorithm)
+		}
+
+		kid, err := thumbprint(signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		ks.Signing = &Key{ID: kid, Method: method, PrivateKey: privateKey, PublicKey: signer.Public()}
+		ks.Verification[kid] = ks.Signing
+
+		// 迁移期间仍接受使用 app.key 签发的旧令牌
+		if config.GetBool("jwt.accept_hmac") {
+			secret := []byte(config.GetString("app.key"))
+			ks.Verification[""] = &Key{Method: jwtpkg.SigningMethodHS256, PrivateKey: secret, PublicKey: secret}
+		}
+	}
+
+	// 3. 读取验签目录下的所有公钥，用于密钥轮换
+	files, _ := filepath.Glob(filepath.Join(config.GetString("jwt.public_keys_path"), "*.pem"))
+	for _, file := range files {
+		pemBytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: read public key %s: %w", file, err)
+		}
+		publicKey, err := parsePublicKeyPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: parse public key %s: %w", file, err)
+		}
+		kid, err := thumbprint(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := ks.Verification[kid]; !exists {
+			ks.Verification[kid] = &Key{ID: kid, Method: defaultMethod(publicKey), PublicKey: publicKey}
+		}
+	}
+
+	return ks, nil
+}
+
+// keyFunc 供 jwtpkg.ParseWithClaims 使用，根据令牌头部的 kid 选择验签公钥
+func (ks *KeySet) keyFunc(token *jwtpkg.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.Verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("jwt: unknown kid %q", kid)
+	}
+	// 算法必须与密钥类型一致，防止算法混淆攻击（如用公钥当作 HMAC 秘钥）
+	if !methodMatchesKey(token.Method, key.PublicKey) {
+		return nil, fmt.Errorf("jwt: algorithm %s not allowed for kid %q", token.Method.Alg(), kid)
+	}
+	return key.PublicKey, nil
+}
+
+// JWKS 以 JSON Web Key Set（RFC 7517）格式导出所有验签公钥，HMAC 秘钥不会导出
+func (ks *KeySet) JWKS() map[string]interface{} {
+	keys := []map[string]string{}
+	for kid, key := range ks.Verification {
+		jwk, err := publicJWK(key.PublicKey)
+		if err != nil {
+			continue
+		}
+		jwk["kid"] = kid
+		jwk["alg"] = key.Method.Alg()
+		jwk["use"] = "sig"
+		keys = append(keys, jwk)
+	}
+	return map[string]interface{}{"keys": keys}
+}
+
+// methodMatchesKey 判断签名算法与密钥类型是否匹配
+func methodMatchesKey(method jwtpkg.SigningMethod, key interface{}) bool {
+	switch method.(type) {
+	case *jwtpkg.SigningMethodHMAC:
+		_, ok := key.([]byte)
+		return ok
+	case *jwtpkg.SigningMethodRSA, *jwtpkg.SigningMethodRSAPSS:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case *jwtpkg.SigningMethodECDSA:
+		pub, ok := key.(*ecdsa.PublicKey)
+		return ok && pub.Curve.Params().BitSize == method.(*jwtpkg.SigningMethodECDSA).CurveBits
+	case *jwtpkg.SigningMethodEd25519:
+		_, ok := key.(ed25519.PublicKey)
+		return ok
+	}
+	return false
+}
+
+// defaultMethod 验签目录中的公钥没有配置算法，根据密钥类型推断
+func defaultMethod(publicKey crypto.PublicKey) jwtpkg.SigningMethod {
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwtpkg.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P384():
+			return jwtpkg.SigningMethodES384
+		case elliptic.P521():
+			return jwtpkg.SigningMethodES512
+		}
+		return jwtpkg.SigningMethodES256
+	case ed25519.PublicKey:
+		return jwtpkg.SigningMethodEdDSA
+	}
+	return jwtpkg.SigningMethodNone
+}
+
+// parsePrivateKeyPEM 解析 PEM 格式的私钥，支持 PKCS#8、PKCS#1 和 SEC 1
+func parsePrivateKeyPEM(pemBytes []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, jwtpkg.ErrKeyMustBePEMEncoded
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unsupported private key format")
+}
+
+// parsePublicKeyPEM 解析 PEM 格式的公钥，支持 PKIX 和 PKCS#1
+func parsePublicKeyPEM(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, jwtpkg.ErrKeyMustBePEMEncoded
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unsupported public key format")
+}
+
+// publicJWK 将公钥转换为 JWK 的必填字段
+func publicJWK(publicKey interface{}) (map[string]string, error) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   b64(pub.N.Bytes()),
+			"e":   b64(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC",
+			"crv": pub.Curve.Params().Name,
+			"x":   b64(padBytes(pub.X.Bytes(), size)),
+			"y":   b64(padBytes(pub.Y.Bytes(), size)),
+		}, nil
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   b64(pub),
+		}, nil
+	}
+	return nil, errors.New("jwt: unsupported public key type")
+}
+
+// thumbprint 计算公钥的 JWK Thumbprint（RFC 7638），作为 kid 使用
+func thumbprint(publicKey interface{}) (string, error) {
+	jwk, err := publicJWK(publicKey)
+	if err != nil {
+		return "", err
+	}
+	// 只保留必填字段，json.Marshal 会按 key 的字典序输出
+	required := map[string]string{"kty": jwk["kty"]}
+	for _, field := range []string{"crv", "e", "n", "x", "y"} {
+		if v, ok := jwk[field]; ok {
+			required[field] = v
+		}
+	}
+	b, err := json.Marshal(required)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// padBytes 左侧补零到固定长度，EC 坐标需要定长编码
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
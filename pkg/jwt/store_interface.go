@@ -0,0 +1,19 @@
+package jwt
+
+import "time"
+
+type Store interface {
+	// Revoke 将 jti 加入黑名单，expiration 后自动移除。
+	// 仅当 jti 之前未被拉黑时返回 true，可用于保证刷新令牌只能使用一次
+	// 存储出错时返回 err，此时无法判断 jti 是否已被拉黑
+	Revoke(jti string, expiration time.Duration) (bool, error)
+
+	// IsRevoked 判断 jti 是否已被拉黑
+	IsRevoked(jti string) bool
+
+	// RevokeFamily 吊销整个令牌家族（同一次登录派生出的所有令牌）
+	RevokeFamily(familyID string, expiration time.Duration) bool
+
+	// IsFamilyRevoked 判断令牌家族是否已被吊销
+	IsFamilyRevoked(familyID string) bool
+}
@@ -0,0 +1,39 @@
+package jwt
+
+import (
+	"gohub/pkg/redis"
+	"time"
+)
+
+// RedisStore 实现 jwt.Store interface
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Revoke 实现 jwt.Store interface 的 Revoke 方法
+func (s *RedisStore) Revoke(jti string, expiration time.Duration) (bool, error) {
+	return s.RedisClient.SetNX(s.storeKey("denylist:"+jti), 1, expiration)
+}
+
+// IsRevoked 实现 jwt.Store interface 的 IsRevoked 方法
+func (s *RedisStore) IsRevoked(jti string) bool {
+	return s.RedisClient.Has(s.storeKey("denylist:" + jti))
+}
+
+// RevokeFamily 实现 jwt.Store interface 的 RevokeFamily 方法
+func (s *RedisStore) RevokeFamily(familyID string, expiration time.Duration) bool {
+	return s.RedisClient.Set(s.storeKey("family:"+familyID), 1, expiration)
+}
+
+// IsFamilyRevoked 实现 jwt.Store interface 的 IsFamilyRevoked 方法
+func (s *RedisStore) IsFamilyRevoked(familyID string) bool {
+	return s.RedisClient.Has(s.storeKey("family:" + familyID))
+}
+
+// storeKey 获取 redis 保存的 key
+func (s *RedisStore) storeKey(key string) string {
+	return s.KeyPrefix + key
+}
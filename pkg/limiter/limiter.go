@@ -1,6 +1,7 @@
 package limiter
 
 import (
+	"gohub/pkg/auth"
 	"gohub/pkg/config"
 	"gohub/pkg/logger"
 	"gohub/pkg/redis"
@@ -21,6 +22,11 @@ func GetKeyRouteWithIP(c *gin.Context) string {
 	return routeToKeyString(c.FullPath()) + c.ClientIP()
 }
 
+// GetKeyUser Limitor 的 Key，名称+当前用户 ID，针对单个用户限流，多个路由使用相同的名称时共享次数
+func GetKeyUser(c *gin.Context, name string) string {
+	return name + ":" + auth.CurrentUID(c)
+}
+
 // CheckRate 检测请求是否超额
 func CheckRate(c *gin.Context, key, formatted string) (limiterlib.Context, error) {
 	// 实例化依赖的 limiter 包的 limiter.Rate 对象
@@ -48,6 +54,11 @@ func CheckRate(c *gin.Context, key, formatted string) (limiterlib.Context, error
 	return limiterObj.Get(c, key)
 }
 
+// ParseRate 解析限流规则，如 "60-M"，用于校验用户输入
+func ParseRate(formatted string) (limiterlib.Rate, error) {
+	return limiterlib.NewRateFromFormatted(formatted)
+}
+
 // routeToKeyString 辅助方法，将 URL 中的 / 格式为 -
 func routeToKeyString(routeName string) string {
 	routeName = strings.ReplaceAll(routeName, "/", "-")
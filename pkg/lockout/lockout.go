@@ -0,0 +1,87 @@
+// Package lockout 登录失败计数、指数退避与账号临时锁定
+package lockout
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/redis"
+	"sync"
+	"time"
+)
+
+// Status 账号的锁定状态，登录相关接口失败时统一返回该结构
+type Status struct {
+	Failures        int64 `json:"failures"`
+	CaptchaRequired bool  `json:"captcha_required"`
+	Locked          bool  `json:"locked"`
+	RetryAfter      int64 `json:"retry_after"` // 距离解锁的秒数
+}
+
+// Lockout 登录失败锁定
+type Lockout struct {
+	Store Store
+}
+
+// once 确保 internalLockout 对象只初始化一次
+var once sync.Once
+
+// internalLockout 内部使用的 Lockout 对象
+var internalLockout *Lockout
+
+// NewLockout 单例模式获取
+func NewLockout() *Lockout {
+	once.Do(func() {
+		internalLockout = &Lockout{
+			Store: &RedisStore{
+				RedisClient: redis.Redis,
+				KeyPrefix:   config.GetString("app.name") + ":lockout:",
+			},
+		}
+	})
+	return internalLockout
+}
+
+// Status 获取 key 当前的锁定状态
+func (l *Lockout) Status(key string) Status {
+	failures := l.Store.Failures(key)
+	status := Status{
+		Failures:        failures,
+		CaptchaRequired: failures >= config.GetInt64("lockout.captcha_threshold"),
+	}
+	if until := l.Store.LockedUntil(key); until > 0 {
+		if retryAfter := until - time.Now().Unix(); retryAfter > 0 {
+			status.Locked = true
+			status.RetryAfter = retryAfter
+		}
+	}
+	return status
+}
+
+// Fail 记录一次失败，达到锁定阈值后每次失败的锁定时长翻倍，返回最新的锁定状态
+func (l *Lockout) Fail(key string) Status {
+	decay := time.Duration(config.GetInt64("lockout.decay_time")) * time.Minute
+	failures := l.Store.IncrementFailures(key, decay)
+
+	if over := failures - config.GetInt64("lockout.lock_threshold"); over >= 0 {
+		d := lockDuration(over)
+		l.Store.Lock(key, time.Now().Add(d).Unix(), d)
+	}
+	return l.Status(key)
+}
+
+// Clear 登录成功或通过验证码解锁后，清除失败记录
+func (l *Lockout) Clear(key string) bool {
+	return l.Store.Clear(key)
+}
+
+// lockDuration 第 over 次超出阈值时的锁定时长：lock_time * 2^over，不超过 max_lock_time
+func lockDuration(over int64) time.Duration {
+	base := time.Duration(config.GetInt64("lockout.lock_time")) * time.Minute
+	max := time.Duration(config.GetInt64("lockout.max_lock_time")) * time.Minute
+	if over > 30 {
+		return max
+	}
+	if d := base << uint(over); d < max {
+		return d
+	}
+	return max
+}
@@ -0,0 +1,20 @@
+package lockout
+
+import "time"
+
+type Store interface {
+	// Failures 获取失败次数
+	Failures(key string) int64
+
+	// IncrementFailures 失败次数加一，返回累计失败次数
+	IncrementFailures(key string, expiration time.Duration) int64
+
+	// Lock 锁定到 until（Unix 时间戳）
+	Lock(key string, until int64, expiration time.Duration) bool
+
+	// LockedUntil 获取锁定截止时间，未锁定时返回 0
+	LockedUntil(key string) int64
+
+	// Clear 清除失败次数和锁定状态
+	Clear(key string) bool
+}
@@ -0,0 +1,47 @@
+package lockout
+
+import (
+	"gohub/pkg/redis"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// RedisStore 实现 lockout.Store interface
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Failures 实现 lockout.Store interface 的 Failures 方法
+func (s *RedisStore) Failures(key string) int64 {
+	return cast.ToInt64(s.RedisClient.Get(s.storeKey("failures:" + key)))
+}
+
+// IncrementFailures 实现 lockout.Store interface 的 IncrementFailures 方法
+func (s *RedisStore) IncrementFailures(key string, expiration time.Duration) int64 {
+	return s.RedisClient.IncrementWithExpire(s.storeKey("failures:"+key), expiration)
+}
+
+// Lock 实现 lockout.Store interface 的 Lock 方法
+func (s *RedisStore) Lock(key string, until int64, expiration time.Duration) bool {
+	return s.RedisClient.Set(s.storeKey("locked:"+key), until, expiration)
+}
+
+// LockedUntil 实现 lockout.Store interface 的 LockedUntil 方法
+func (s *RedisStore) LockedUntil(key string) int64 {
+	return cast.ToInt64(s.RedisClient.Get(s.storeKey("locked:" + key)))
+}
+
+// Clear 实现 lockout.Store interface 的 Clear 方法
+func (s *RedisStore) Clear(key string) bool {
+	s.RedisClient.Del(s.storeKey("locked:" + key))
+	return s.RedisClient.Del(s.storeKey("failures:" + key))
+}
+
+// storeKey 获取 redis 保存的 key
+func (s *RedisStore) storeKey(key string) string {
+	return s.KeyPrefix + key
+}
@@ -0,0 +1,188 @@
+package markdown
+
+import "strings"
+
+// language 代码高亮的语言定义，只区分注释、字符串、数字和关键字，
+// 输出 hl-comment、hl-string、hl-number、hl-keyword 样式类，配色由前端负责
+type language struct {
+	keywords     []string
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+}
+
+var (
+	cLike = language{
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"'`,
+	}
+
+	languages = map[string]language{
+		"go":         withKeywords(cLike, "break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false", "`"),
+		"javascript": withKeywords(cLike, "async await break case catch class const continue default delete do else export extends false finally for function if import in instanceof let new null return super switch this throw true try typeof undefined var void while yield", "`"),
+		"typescript": withKeywords(cLike, "abstract any async await boolean break case catch class const continue default do else enum export extends false finally for from function if implements import in interface let new null number private protected public readonly return string super switch this throw true try type typeof undefined var void while", "`"),
+		"java":       withKeywords(cLike, "abstract boolean break byte case catch char class continue default do double else enum extends false final finally float for if implements import instanceof int interface long new null package private protected public return short static super switch this throw throws true try void while", ""),
+		"c":          withKeywords(cLike, "auto break case char const continue default do double else enum extern float for goto if int long register return short signed sizeof static struct switch typedef union unsigned void volatile while NULL", ""),
+		"cpp":        withKeywords(cLike, "auto bool break case catch char class const continue default delete do double else enum false float for if int long namespace new nullptr private protected public return short static struct switch template this throw true try typename using virtual void while", ""),
+		"rust":       withKeywords(cLike, "as break const continue crate else enum extern false fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while", ""),
+		"php": {
+			keywords:     strings.Fields("abstract array as break case catch class const continue default do echo else elseif extends false final for foreach function if implements interface namespace new null private protected public return static switch throw true try use while"),
+			lineComments: []string{"//", "#"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       `"'`,
+		},
+		"python": {
+			keywords:     strings.Fields("and as assert async await break class continue def del elif else except False finally for from global if import in is lambda None nonlocal not or pass raise return True try while with yield"),
+			lineComments: []string{"#"},
+			quotes:       `"'`,
+		},
+		"ruby": {
+			keywords:     strings.Fields("begin break case class def do else elsif end ensure false for if in module next nil not rescue return self super then true unless until when while yield"),
+			lineComments: []string{"#"},
+			quotes:       `"'`,
+		},
+		"bash": {
+			keywords:     strings.Fields("case do done elif else esac export fi for function if in local return then until while"),
+			lineComments: []string{"#"},
+			quotes:       `"'`,
+		},
+		"sql": {
+			keywords:     strings.Fields("ADD ALTER AND AS ASC BY CREATE DELETE DESC DISTINCT DROP FROM GROUP HAVING IN INDEX INNER INSERT INTO IS JOIN KEY LEFT LIKE LIMIT NOT NULL ON OR ORDER PRIMARY SELECT SET TABLE UNION UPDATE VALUES WHERE"),
+			lineComments: []string{"--", "#"},
+			blockComment: [2]string{"/*", "*/"},
+			quotes:       `"'`,
+		},
+	}
+
+	aliases = map[string]string{
+		"golang": "go",
+		"js":     "javascript",
+		"jsx":    "javascript",
+		"ts":     "typescript",
+		"tsx":    "typescript",
+		"h":      "c",
+		"c++":    "cpp",
+		"rs":     "rust",
+		"py":     "python",
+		"rb":     "ruby",
+		"sh":     "bash",
+		"shell":  "bash",
+		"zsh":    "bash",
+		"mysql":  "sql",
+	}
+)
+
+func withKeywords(base language, keywords string, extraQuotes string) language {
+	base.keywords = strings.Fields(keywords)
+	base.quotes += extraQuotes
+	return base
+}
+
+// highlight 返回转义并高亮后的代码，未知语言只做转义
+func highlight(code, lang string) string {
+	if alias, ok := aliases[lang]; ok {
+		lang = alias
+	}
+	def, ok := languages[lang]
+	if !ok {
+		return escapeHTML(code)
+	}
+
+	keywords := make(map[string]bool, len(def.keywords))
+	for _, keyword := range def.keywords {
+		keywords[keyword] = true
+	}
+	// SQL 关键字不区分大小写
+	ignoreCase := lang == "sql"
+
+	var b strings.Builder
+	span := func(class, text string) {
+		b.WriteString(`<span class="hl-` + class + `">` + escapeHTML(text) + `</span>`)
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+
+		if prefix := matchPrefix(rest, def.lineComments); len(prefix) > 0 {
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			span("comment", rest[:end])
+			i += end
+			continue
+		}
+
+		if open := def.blockComment[0]; len(open) > 0 && strings.HasPrefix(rest, open) {
+			end := strings.Index(rest[len(open):], def.blockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(open) + len(def.blockComment[1])
+			}
+			span("comment", rest[:end])
+			i += end
+			continue
+		}
+
+		c := code[i]
+		switch {
+		case strings.IndexByte(def.quotes, c) >= 0:
+			end := 1
+			for end < len(rest) && rest[end] != c {
+				// 只有反引号字符串可以跨行
+				if rest[end] == '\n' && c != '`' {
+					break
+				}
+				if rest[end] == '\\' && c != '`' {
+					end++
+				}
+				end++
+			}
+			if end < len(rest) && rest[end] == c {
+				end++
+			}
+			if end > len(rest) {
+				end = len(rest)
+			}
+			span("string", rest[:end])
+			i += end
+
+		case '0' <= c && c <= '9' && (i == 0 || !isWordChar(code[i-1])):
+			end := 1
+			for end < len(rest) && (isWordChar(rest[end]) || rest[end] == '.') {
+				end++
+			}
+			span("number", rest[:end])
+			i += end
+
+		case isWordChar(c):
+			end := 1
+			for end < len(rest) && isWordChar(rest[end]) {
+				end++
+			}
+			word := rest[:end]
+			if keywords[word] || ignoreCase && keywords[strings.ToUpper(word)] {
+				span("keyword", word)
+			} else {
+				b.WriteString(word)
+			}
+			i += end
+
+		default:
+			b.WriteString(escapeHTML(rest[:1]))
+			i++
+		}
+	}
+	return b.String()
+}
+
+func matchPrefix(s string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+// Package markdown 将话题内容的 Markdown 渲染为 HTML，基于 goldmark，支持 CommonMark 和 GFM 的删除线、自动链接，
+// 不支持原始 HTML（原样转义）。渲染结果再经 Sanitize（bluemonday）按白名单过滤，可直接输出到页面
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Options 渲染选项
+type Options struct {
+	// MentionURL 返回 @用户名 的链接，用户不存在时 ok 为 false，不生成链接
+	MentionURL func(name string) (url string, ok bool)
+	// TopicURL 返回 #话题ID 的链接，话题不存在时 ok 为 false，不生成链接
+	TopicURL func(id string) (url string, ok bool)
+}
+
+// Result 渲染结果
+type Result struct {
+	HTML string
+	// 内容中 @ 到的用户名和 # 引用的话题 ID，只包含存在的用户和话题，已去重
+	Mentions []string
+	TopicIDs []string
+}
+
+// md goldmark 实例，可并发使用；@用户名 和 #话题ID 的链接在 Render 中按 Options 解析
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+	goldmark.WithParserOptions(
+		parser.WithInlineParsers(
+			util.Prioritized(&referenceParser{prefix: '@', pattern: mentionRegex}, 500),
+			util.Prioritized(&referenceParser{prefix: '#', pattern: topicRefRegex}, 500),
+		),
+	),
+	goldmark.WithRendererOptions(
+		renderer.WithNodeRenderers(
+			// 数值小于默认渲染器的 1000，覆盖代码块和原始 HTML 的默认渲染
+			util.Prioritized(&nodeRenderer{}, 200),
+		),
+	),
+)
+
+// Render 渲染 Markdown，调用示例：
+//         result := markdown.Render(topic.Body, markdown.Options{MentionURL: ..., TopicURL: ...})
+func Render(source string, opts Options) Result {
+	src := []byte(strings.ReplaceAll(source, "\r\n", "\n"))
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	var result Result
+	resolveReferences(doc, opts, &result)
+
+	var b bytes.Buffer
+	if err := md.Renderer().Render(&b, src, doc); err != nil {
+		// 写入内存不会失败，这里只做兜底，原样转义输出
+		return Result{HTML: "<p>" + escapeHTML(source) + "</p>"}
+	}
+	result.HTML = Sanitize(b.String())
+	return result
+}
+
+// nodeRenderer 代码块使用 highlight 高亮，原始 HTML 原样转义为文字
+type nodeRenderer struct{}
+
+func (r *nodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+	reg.Register(ast.KindRawHTML, r.renderRawHTML)
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+	reg.Register(KindReference, r.renderReference)
+}
+
+func (r *nodeRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+	lang := strings.ToLower(string(n.Language(source)))
+
+	var code strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		code.Write(line.Value(source))
+	}
+
+	w.WriteString("<pre><code")
+	if len(lang) > 0 {
+		w.WriteString(` class="language-` + escapeHTML(lang) + `"`)
+	}
+	w.WriteString(">" + highlight(code.String(), lang) + "</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderRawHTML(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	n := node.(*ast.RawHTML)
+	for i := 0; i < n.Segments.Len(); i++ {
+		segment := n.Segments.At(i)
+		w.WriteString(escapeHTML(string(segment.Value(source))))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderHTMLBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.HTMLBlock)
+	var content strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		content.Write(line.Value(source))
+	}
+	if n.HasClosure() {
+		content.Write(n.ClosureLine.Value(source))
+	}
+	w.WriteString("<p>" + escapeHTML(strings.TrimRight(content.String(), "\n")) + "</p>\n")
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,133 @@
+package markdown
+
+import (
+	"regexp"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	mentionRegex  = regexp.MustCompile(`^@([A-Za-z0-9]{3,20})`)
+	topicRefRegex = regexp.MustCompile(`^#(\d{1,20})`)
+)
+
+// KindReference @用户名 和 #话题ID 节点的类型
+var KindReference = ast.NewNodeKind("Reference")
+
+// Reference @用户名 或 #话题ID，URL 为空时按文字输出
+type Reference struct {
+	ast.BaseInline
+
+	Prefix byte
+	Name   string
+	URL    string
+}
+
+func (n *Reference) Kind() ast.NodeKind {
+	return KindReference
+}
+
+func (n *Reference) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Name": n.Name, "URL": n.URL}, nil)
+}
+
+// Literal 节点的原文，如 @summer
+func (n *Reference) Literal() string {
+	return string(n.Prefix) + n.Name
+}
+
+// referenceParser 解析 @用户名 或 #话题ID，前后必须是单词边界，避免匹配邮箱地址和 URL 锚点
+type referenceParser struct {
+	prefix  byte
+	pattern *regexp.Regexp
+}
+
+func (p *referenceParser) Trigger() []byte {
+	return []byte{p.prefix}
+}
+
+func (p *referenceParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if isWordRune(block.PrecendingCharacter()) {
+		return nil
+	}
+	line, _ := block.PeekLine()
+	m := p.pattern.FindSubmatch(line)
+	if m == nil || len(m[0]) < len(line) && isWordChar(line[len(m[0])]) {
+		return nil
+	}
+	block.Advance(len(m[0]))
+	return &Reference{Prefix: p.prefix, Name: string(m[1])}
+}
+
+// resolveReferences 按 Options 生成 @用户名 和 #话题ID 的链接，并记录到 result；
+// 用户或话题不存在、或位于链接文字中时替换为普通文字
+func resolveReferences(doc ast.Node, opts Options, result *Result) {
+	var references []*Reference
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if n, ok := node.(*Reference); ok && entering {
+			references = append(references, n)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	seen := make(map[string]bool)
+	for _, n := range references {
+		lookup, list := opts.MentionURL, &result.Mentions
+		if n.Prefix == '#' {
+			lookup, list = opts.TopicURL, &result.TopicIDs
+		}
+
+		ok := lookup != nil && !inLink(n)
+		if ok {
+			n.URL, ok = lookup(n.Name)
+		}
+		if !ok {
+			n.Parent().ReplaceChild(n.Parent(), n, ast.NewString([]byte(n.Literal())))
+			continue
+		}
+		if !seen[n.Literal()] {
+			seen[n.Literal()] = true
+			*list = append(*list, n.Name)
+		}
+	}
+}
+
+// inLink 节点是否位于链接中
+func inLink(node ast.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.Kind() {
+		case ast.KindLink, ast.KindAutoLink:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *nodeRenderer) renderReference(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	n := node.(*Reference)
+	if len(n.URL) == 0 {
+		w.WriteString(escapeHTML(n.Literal()))
+		return ast.WalkSkipChildren, nil
+	}
+	class := "mention"
+	if n.Prefix == '#' {
+		class = "topic-link"
+	}
+	w.WriteString(`<a href="` + escapeHTML(n.URL) + `" class="` + class + `">` + escapeHTML(n.Literal()) + `</a>`)
+	return ast.WalkSkipChildren, nil
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
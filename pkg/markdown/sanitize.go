@@ -0,0 +1,87 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	xhtml "golang.org/x/net/html"
+)
+
+var (
+	// inlineTags 行内标签，提取摘要时不在这些标签处断开
+	inlineTags = map[string]bool{"a": true, "em": true, "strong": true, "del": true, "code": true, "span": true}
+
+	// allowedClassRegex 允许的 class：代码语言、代码高亮、@用户名 和 #话题ID
+	allowedClassRegex = regexp.MustCompile(`^(?:language-[a-z0-9+#-]+|hl-(?:comment|string|number|keyword)|mention|topic-link)$`)
+
+	// policy 白名单，只允许 Markdown 能生成的标签和属性，链接只允许 http、https、mailto 协议和相对地址
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"blockquote", "ul", "ol", "li", "pre", "em", "strong", "del",
+	)
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("class").Matching(allowedClassRegex).OnElements("code", "span", "a")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.AllowAttrs("src", "alt", "title").OnElements("img")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowRelativeURLs(true)
+	p.RequireParseableURLs(true)
+	// 没有 class 的 code 和 span 同样保留
+	p.AllowElements("code", "span")
+	return p
+}
+
+// Sanitize 按白名单过滤 HTML：不在白名单中的标签只保留文字（script 等连同内容删除），
+// 不在白名单中的属性和不安全的链接被移除
+func Sanitize(input string) string {
+	return policy.Sanitize(input)
+}
+
+// Excerpt 从渲染后的 HTML 中提取纯文本摘要，代码块不计入摘要，超出 length 个字符时截断
+func Excerpt(source string, length int) string {
+	var b strings.Builder
+	inPre := 0
+	z := xhtml.NewTokenizer(strings.NewReader(source))
+	for done := false; !done; {
+		switch z.Next() {
+		case xhtml.ErrorToken:
+			done = true
+		case xhtml.TextToken:
+			if inPre == 0 {
+				b.Write(z.Text())
+			}
+		case xhtml.StartTagToken, xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if tag == "pre" {
+				if z.Token().Type == xhtml.EndTagToken {
+					inPre--
+				} else {
+					inPre++
+				}
+			}
+			// 块级标签处断开，避免相邻段落的文字粘连
+			if !inlineTags[tag] {
+				b.WriteString(" ")
+			}
+		}
+	}
+
+	text := strings.Join(strings.Fields(b.String()), " ")
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length]) + "..."
+}
+
+func escapeHTML(s string) string {
+	return html.EscapeString(s)
+}
@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"encoding/json"
+	"gohub/pkg/database"
+	"time"
+)
+
+// Database 站内信渠道，通知写入数据库，由 notification 模型读取
+type Database struct {
+	Table string
+}
+
+var _ Channel = (*Database)(nil)
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (d *Database) Send(to Recipient, message Message) error {
+	msg, ok := message.(DatabaseMessage)
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(msg.ToDatabase())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return database.DB.Table(d.Table).Create(map[string]interface{}{
+		"user_id":    to.ID,
+		"type":       msg.Type(),
+		"data":       string(data),
+		"created_at": now,
+		"updated_at": now,
+	}).Error
+}
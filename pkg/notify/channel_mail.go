@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/mail"
+)
+
+// Mail 邮件渠道
+type Mail struct{}
+
+var _ Channel = (*Mail)(nil)
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (m *Mail) Send(to Recipient, message Message) error {
+	msg, ok := message.(MailMessage)
+	if !ok || len(to.Email) == 0 {
+		return nil
+	}
+	subject, html := msg.ToMail()
+	ok = mail.NewMailer().Send(mail.Email{
+		From: mail.From{
+			Address: config.GetString("mail.from.address"),
+			Name:    config.GetString("mail.from.name"),
+		},
+		To:      []string{to.Email},
+		Subject: subject,
+		HTML:    []byte(html),
+	})
+	if !ok {
+		return errors.New("通知邮件发送失败：" + msg.Type())
+	}
+	return nil
+}
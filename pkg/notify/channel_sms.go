@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"errors"
+	"gohub/pkg/sms"
+)
+
+// SMS 短信渠道
+type SMS struct{}
+
+var _ Channel = (*SMS)(nil)
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (s *SMS) Send(to Recipient, message Message) error {
+	msg, ok := message.(SMSMessage)
+	if !ok || len(to.Phone) == 0 {
+		return nil
+	}
+	if ok := sms.NewSMS().Send(to.Phone, msg.ToSMS()); !ok {
+		return errors.New("通知短信发送失败：" + msg.Type())
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package notify 通知分发，同一条通知可经站内信（数据库）、邮件和短信等渠道发送给用户
+package notify
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/sms"
+	"sync"
+)
+
+// 通知渠道
+const (
+	ChannelDatabase = "database"
+	ChannelMail     = "mail"
+	ChannelSMS      = "sms"
+)
+
+// Recipient 通知的接收人，Email 和 Phone 为空时不发送对应渠道的通知
+type Recipient struct {
+	ID    string
+	Email string
+	Phone string
+}
+
+// Message 通知，Type 区分通知的类型，用户按类型设置接收的渠道
+// 通知实现了某个渠道的方法（如 ToMail）才会经该渠道发送
+type Message interface {
+	Type() string
+}
+
+// DatabaseMessage 站内信，ToDatabase 返回的负载以 JSON 格式保存
+type DatabaseMessage interface {
+	Message
+	ToDatabase() interface{}
+}
+
+// MailMessage 邮件通知，返回邮件标题和 HTML 内容
+type MailMessage interface {
+	Message
+	ToMail() (subject, html string)
+}
+
+// SMSMessage 短信通知
+type SMSMessage interface {
+	Message
+	ToSMS() sms.Message
+}
+
+// Channel 通知渠道
+type Channel interface {
+	// Send 发送通知，通知未实现该渠道的方法或接收人缺少联系方式时不做任何处理
+	Send(to Recipient, message Message) error
+}
+
+// Notifier 通知分发器
+type Notifier struct {
+	Channels map[string]Channel
+}
+
+// once 确保 internalNotifier 对象只初始化一次
+var once sync.Once
+
+// internalNotifier 内部使用的 Notifier 对象
+var internalNotifier *Notifier
+
+// NewNotifier 单例模式获取
+func NewNotifier() *Notifier {
+	once.Do(func() {
+		internalNotifier = &Notifier{
+			Channels: map[string]Channel{
+				ChannelDatabase: &Database{Table: config.GetString("notify.table")},
+				ChannelMail:     &Mail{},
+				ChannelSMS:      &SMS{},
+			},
+		}
+	})
+	return internalNotifier
+}
+
+// Send 经 channels 发送通知，调用示例：
+//         notify.NewNotifier().Send(recipient, notifications.TopicVoted{...}, []string{"database", "mail"})
+// 站内信同步写入，写入失败时返回错误；邮件和短信较慢，异步发送，发送失败只记录日志
+func (n *Notifier) Send(to Recipient, message Message, channels []string) error {
+	var err error
+	for _, name := range channels {
+		channel, ok := n.Channels[name]
+		if !ok {
+			logger.WarnString("通知", "发送", "未知的通知渠道 "+name)
+			continue
+		}
+		if name == ChannelDatabase {
+			if e := channel.Send(to, message); e != nil {
+				err = e
+			}
+			continue
+		}
+		go func(channel Channel) {
+			logger.LogIf(channel.Send(to, message))
+		}(channel)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"github.com/spf13/cast"
+)
+
+// GitHub 使用 GitHub OAuth App 登录
+type GitHub struct {
+	Endpoint
+}
+
+var _ Provider = (*GitHub)(nil)
+
+// NewGitHub 创建 GitHub 登录平台
+func NewGitHub(clientID, clientSecret string) *GitHub {
+	return &GitHub{Endpoint{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  CallbackURL("github"),
+		AuthURL:      "https://github.com/login/oauth/authorize",
+		TokenURL:     "https://github.com/login/oauth/access_token",
+		Scopes:       []string{"read:user", "user:email"},
+	}}
+}
+
+// Name 实现 oauth.Provider interface 的 Name 方法
+func (p *GitHub) Name() string {
+	return "github"
+}
+
+// AuthCodeURL 实现 oauth.Provider interface 的 AuthCodeURL 方法
+func (p *GitHub) AuthCodeURL(state, codeChallenge string) string {
+	return p.authCodeURL(state, codeChallenge, nil)
+}
+
+// Exchange 实现 oauth.Provider interface 的 Exchange 方法
+func (p *GitHub) Exchange(code, codeVerifier string) (Token, error) {
+	return p.exchange(code, codeVerifier)
+}
+
+// UserInfo 实现 oauth.Provider interface 的 UserInfo 方法
+func (p *GitHub) UserInfo(token Token) (Identity, error) {
+	var profile struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getJSON("https://api.github.com/user", token.AccessToken, &profile); err != nil {
+		return Identity{}, err
+	}
+
+	identity := Identity{
+		Subject: cast.ToString(profile.ID),
+		Name:    profile.Login,
+		Avatar:  profile.AvatarURL,
+	}
+
+	// 公开资料中的 Email 未必经过验证，以邮箱接口返回的主邮箱为准
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON("https://api.github.com/user/emails", token.AccessToken, &emails); err == nil {
+		for _, e := range emails {
+			if e.Primary {
+				identity.Email = e.Email
+				identity.EmailVerified = e.Verified
+			}
+		}
+	}
+	return identity, nil
+}
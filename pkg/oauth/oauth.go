@@ -0,0 +1,162 @@
+// Package oauth 第三方登录，基于 OAuth2 授权码模式 + PKCE（RFC 7636）
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ActionLogin 使用第三方账号登录（或注册）
+	ActionLogin = "login"
+	// ActionLink 为已登录的账号绑定第三方账号
+	ActionLink = "link"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oauth: provider not found")
+	ErrInvalidState     = errors.New("oauth: invalid or expired state")
+)
+
+// Identity 第三方平台返回的用户信息
+type Identity struct {
+	Provider      string
+	Subject       string // 用户在第三方平台的唯一标识
+	Name          string
+	Email         string
+	EmailVerified bool
+	Avatar        string
+}
+
+// Token 授权码换取的令牌
+type Token struct {
+	AccessToken  string            `json:"access_token"`
+	TokenType    string            `json:"token_type"`
+	RefreshToken string            `json:"refresh_token"`
+	ExpiresIn    int64             `json:"expires_in"`
+	IDToken      string            `json:"id_token"`
+	Extra        map[string]string `json:"-"` // 非标准字段，如微信的 openid
+}
+
+// Provider 第三方登录平台，新增平台时实现该接口并调用 Register 注册
+type Provider interface {
+	// Name 平台名称，用于路由参数和 user_identities.provider 字段
+	Name() string
+
+	// AuthCodeURL 跳转到第三方平台授权页的链接
+	AuthCodeURL(state, codeChallenge string) string
+
+	// Exchange 使用授权码换取令牌
+	Exchange(code, codeVerifier string) (Token, error)
+
+	// UserInfo 使用令牌获取用户信息
+	UserInfo(token Token) (Identity, error)
+}
+
+// State 发起授权时保存在 Redis 中的上下文，回调时校验并取出
+type State struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Action       string `json:"action"`
+	UserID       string `json:"user_id,omitempty"`
+	DeviceName   string `json:"device_name,omitempty"`
+}
+
+// OAuth 第三方登录流程
+type OAuth struct {
+	Store Store
+}
+
+// once 确保 internalOAuth 对象只初始化一次
+var once sync.Once
+
+// internalOAuth 内部使用的 OAuth 对象
+var internalOAuth *OAuth
+
+// NewOAuth 单例模式获取
+func NewOAuth() *OAuth {
+	once.Do(func() {
+		internalOAuth = &OAuth{
+			Store: &RedisStore{
+				RedisClient: redis.Redis,
+				KeyPrefix:   config.GetString("app.name") + ":oauth:",
+			},
+		}
+	})
+	return internalOAuth
+}
+
+// Begin 发起授权，生成 state 和 PKCE 校验码并保存，返回第三方授权页的链接
+func (o *OAuth) Begin(provider Provider, st State) string {
+	st.Provider = provider.Name()
+	st.CodeVerifier = RandomToken(32)
+
+	state := RandomToken(24)
+	b, _ := json.Marshal(st)
+	o.Store.Set("state:"+state, string(b), stateExpireTime())
+
+	return provider.AuthCodeURL(state, CodeChallenge(st.CodeVerifier))
+}
+
+// Complete 处理授权回调：校验 state（只能使用一次），换取令牌并获取用户信息
+func (o *OAuth) Complete(providerName, state, code string) (Identity, State, error) {
+	var st State
+	val := o.Store.Pull("state:" + state)
+	if len(val) == 0 {
+		return Identity{}, st, ErrInvalidState
+	}
+	if err := json.Unmarshal([]byte(val), &st); err != nil || st.Provider != providerName {
+		return Identity{}, st, ErrInvalidState
+	}
+
+	provider, ok := Get(providerName)
+	if !ok {
+		return Identity{}, st, ErrProviderNotFound
+	}
+	token, err := provider.Exchange(code, st.CodeVerifier)
+	if err != nil {
+		logger.LogIf(err)
+		return Identity{}, st, err
+	}
+	identity, err := provider.UserInfo(token)
+	if err != nil {
+		logger.LogIf(err)
+		return Identity{}, st, err
+	}
+	identity.Provider = providerName
+	return identity, st, nil
+}
+
+// CodeChallenge 使用 S256 方式计算 PKCE 的 code_challenge
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// RandomToken 生成 URL 安全的随机字符串，n 为随机字节数
+func RandomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		logger.LogIf(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// CallbackURL 授权回调地址
+func CallbackURL(providerName string) string {
+	return strings.ReplaceAll(config.GetString("oauth.callback_url"), "{provider}", providerName)
+}
+
+// stateExpireTime 授权流程的有效期
+func stateExpireTime() time.Duration {
+	return time.Duration(config.GetInt64("oauth.state_expire_time", 10)) * time.Minute
+}
@@ -0,0 +1,103 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// httpClient 请求第三方平台使用的客户端
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Endpoint 标准 OAuth2 平台的客户端配置和接口地址
+type Endpoint struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	Scopes       []string
+}
+
+// authCodeURL 拼接授权页链接，extra 为平台特有的参数
+func (e Endpoint) authCodeURL(state, codeChallenge string, extra url.Values) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", e.ClientID)
+	params.Set("redirect_uri", e.RedirectURL)
+	params.Set("scope", strings.Join(e.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+	for k, v := range extra {
+		params[k] = v
+	}
+
+	sep := "?"
+	if strings.Contains(e.AuthURL, "?") {
+		sep = "&"
+	}
+	return e.AuthURL + sep + params.Encode()
+}
+
+// exchange 使用授权码和 PKCE 校验码换取令牌
+func (e Endpoint) exchange(code, codeVerifier string) (Token, error) {
+	var token Token
+	err := postForm(e.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {e.RedirectURL},
+		"client_id":     {e.ClientID},
+		"client_secret": {e.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}, &token)
+	if err == nil && len(token.AccessToken) == 0 {
+		err = fmt.Errorf("oauth: token endpoint %s returned no access_token", e.TokenURL)
+	}
+	return token, err
+}
+
+// postForm 以表单提交，解析 JSON 响应
+func postForm(endpoint string, values url.Values, out interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doJSON(req, out)
+}
+
+// getJSON 发送 GET 请求，accessToken 不为空时以 Bearer 方式携带
+func getJSON(endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if len(accessToken) > 0 {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return doJSON(req, out)
+}
+
+// doJSON 发送请求，非 2xx 响应视为错误
+func doJSON(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("oauth: %s %s: %s %s", req.Method, req.URL.Host+req.URL.Path, resp.Status, body)
+	}
+	return json.Unmarshal(body, out)
+}
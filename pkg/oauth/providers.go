@@ -0,0 +1,70 @@
+package oauth
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"sort"
+	"sync"
+)
+
+var (
+	providersOnce sync.Once
+	providersMu   sync.RWMutex
+	providers     = map[string]Provider{}
+)
+
+// Register 注册第三方登录平台，同名平台会被覆盖
+func Register(provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[provider.Name()] = provider
+}
+
+// Get 获取已启用的平台
+func Get(name string) (Provider, bool) {
+	loadProviders()
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[name]
+	return p, ok
+}
+
+// Names 所有已启用的平台名称
+func Names() []string {
+	loadProviders()
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadProviders 根据 config/oauth.go 启用内置平台，未配置 client_id 的平台不启用
+func loadProviders() {
+	providersOnce.Do(func() {
+		if id := config.GetString("oauth.github.client_id"); len(id) > 0 {
+			Register(NewGitHub(id, config.GetString("oauth.github.client_secret")))
+		}
+		if id := config.GetString("oauth.google.client_id"); len(id) > 0 {
+			Register(NewOIDC("google", "https://accounts.google.com", id, config.GetString("oauth.google.client_secret")))
+		}
+		if id := config.GetString("oauth.wechat.app_id"); len(id) > 0 {
+			Register(NewWeChat(id, config.GetString("oauth.wechat.app_secret")))
+		}
+		if id := config.GetString("oauth.oidc.client_id"); len(id) > 0 {
+			Register(NewOIDC(
+				config.GetString("oauth.oidc.name", "oidc"),
+				config.GetString("oauth.oidc.issuer"),
+				id,
+				config.GetString("oauth.oidc.client_secret"),
+			))
+		}
+		// 模拟平台任何人都能以任意身份登录，生产环境下始终不启用
+		if config.GetBool("oauth.fake.enabled") && !app.IsProduction() {
+			Register(NewFake(NewOAuth().Store))
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package oauth
+
+import "time"
+
+type Store interface {
+	// Set 保存数据
+	Set(key, value string, expiration time.Duration) bool
+
+	// Get 获取数据，不存在时返回空字符串
+	Get(key string) string
+
+	// Pull 获取并删除数据，用于只能使用一次的 state 和授权码
+	Pull(key string) string
+}
@@ -0,0 +1,37 @@
+package oauth
+
+import (
+	"gohub/pkg/redis"
+	"time"
+)
+
+// RedisStore 实现 oauth.Store interface
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Set 实现 oauth.Store interface 的 Set 方法
+func (s *RedisStore) Set(key, value string, expiration time.Duration) bool {
+	return s.RedisClient.Set(s.KeyPrefix+key, value, expiration)
+}
+
+// Get 实现 oauth.Store interface 的 Get 方法
+func (s *RedisStore) Get(key string) string {
+	return s.RedisClient.Get(s.KeyPrefix + key)
+}
+
+// Pull 实现 oauth.Store interface 的 Pull 方法
+func (s *RedisStore) Pull(key string) string {
+	val := s.RedisClient.Get(s.KeyPrefix + key)
+	if len(val) == 0 {
+		return ""
+	}
+	// 并发请求时只有成功删除的一方可以使用
+	if deleted, _ := s.RedisClient.Client.Del(s.RedisClient.Context, s.KeyPrefix+key).Result(); deleted != 1 {
+		return ""
+	}
+	return val
+}
@@ -0,0 +1,104 @@
+package oauth
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WeChat 微信开放平台网站应用扫码登录
+// 微信的接口不是标准 OAuth2：参数名为 appid/secret，不支持 PKCE，令牌响应中附带 openid
+type WeChat struct {
+	AppID     string
+	AppSecret string
+}
+
+var _ Provider = (*WeChat)(nil)
+
+// wechatError 微信接口出错时返回 errcode 和 errmsg
+type wechatError struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// NewWeChat 创建微信登录平台
+func NewWeChat(appID, appSecret string) *WeChat {
+	return &WeChat{AppID: appID, AppSecret: appSecret}
+}
+
+// Name 实现 oauth.Provider interface 的 Name 方法
+func (p *WeChat) Name() string {
+	return "wechat"
+}
+
+// AuthCodeURL 实现 oauth.Provider interface 的 AuthCodeURL 方法
+func (p *WeChat) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("appid", p.AppID)
+	params.Set("redirect_uri", CallbackURL(p.Name()))
+	params.Set("response_type", "code")
+	params.Set("scope", "snsapi_login")
+	params.Set("state", state)
+	return "https://open.weixin.qq.com/connect/qrconnect?" + params.Encode() + "#wechat_redirect"
+}
+
+// Exchange 实现 oauth.Provider interface 的 Exchange 方法
+func (p *WeChat) Exchange(code, codeVerifier string) (Token, error) {
+	params := url.Values{}
+	params.Set("appid", p.AppID)
+	params.Set("secret", p.AppSecret)
+	params.Set("code", code)
+	params.Set("grant_type", "authorization_code")
+
+	var resp struct {
+		wechatError
+		AccessToken  string `json:"access_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+		OpenID       string `json:"openid"`
+		UnionID      string `json:"unionid"`
+	}
+	if err := getJSON("https://api.weixin.qq.com/sns/oauth2/access_token?"+params.Encode(), "", &resp); err != nil {
+		return Token{}, err
+	}
+	if resp.ErrCode != 0 {
+		return Token{}, fmt.Errorf("oauth: wechat access_token: %d %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return Token{
+		AccessToken:  resp.AccessToken,
+		ExpiresIn:    resp.ExpiresIn,
+		RefreshToken: resp.RefreshToken,
+		Extra:        map[string]string{"openid": resp.OpenID, "unionid": resp.UnionID},
+	}, nil
+}
+
+// UserInfo 实现 oauth.Provider interface 的 UserInfo 方法
+func (p *WeChat) UserInfo(token Token) (Identity, error) {
+	params := url.Values{}
+	params.Set("access_token", token.AccessToken)
+	params.Set("openid", token.Extra["openid"])
+
+	var resp struct {
+		wechatError
+		OpenID     string `json:"openid"`
+		UnionID    string `json:"unionid"`
+		Nickname   string `json:"nickname"`
+		HeadImgURL string `json:"headimgurl"`
+	}
+	if err := getJSON("https://api.weixin.qq.com/sns/userinfo?"+params.Encode(), "", &resp); err != nil {
+		return Identity{}, err
+	}
+	if resp.ErrCode != 0 {
+		return Identity{}, fmt.Errorf("oauth: wechat userinfo: %d %s", resp.ErrCode, resp.ErrMsg)
+	}
+
+	// 同一开放平台下的多个应用 openid 不同，优先使用 unionid
+	subject := resp.UnionID
+	if len(subject) == 0 {
+		subject = resp.OpenID
+	}
+	return Identity{
+		Subject: subject,
+		Name:    resp.Nickname,
+		Avatar:  resp.HeadImgURL,
+	}, nil
+}
@@ -0,0 +1,274 @@
+package paginator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidCursor 游标格式错误、签名不正确或排序字段不存在
+var ErrInvalidCursor = errors.New("paginator: invalid cursor")
+
+// CursorPage 游标分页信息，使用 next_cursor 或 prev_cursor 的值作为 cursor 参数请求下一页或上一页
+type CursorPage struct {
+	PerPage    int    `json:"per_page"`
+	NextCursor string `json:"next_cursor"` // 为空表示没有下一页
+	PrevCursor string `json:"prev_cursor"` // 为空表示没有上一页
+}
+
+// cursor 游标的内容，包含排序字段及最后一条（向前翻页时为第一条）数据的排序值和 ID
+// Leading 为前置排序字段的值，与 CursorPaginate 的 leading 参数一一对应
+type cursor struct {
+	Sort     string            `json:"s"`
+	Order    string            `json:"o"`
+	Value    json.RawMessage   `json:"v"`
+	ID       uint64            `json:"i"`
+	Leading  []json.RawMessage `json:"l,omitempty"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+// cursorKey 参与 keyset 比较的一个字段
+type cursorKey struct {
+	field *schema.Field
+	order string
+	value interface{}
+}
+
+// CursorPaginate 游标分页（keyset pagination），不使用 COUNT(*) 和 OFFSET，适合数据量大、不断有新数据的列表
+// 排序规则使用 sort 参数的第一个字段，再以 id 保证顺序唯一，排序字段不能有 NULL 值
+// 请求带 cursor 参数时，使用游标中的排序规则，忽略 sort 和 order 参数
+// leading 为排在排序字段之前的字段，均按降序排列，如分类下置顶的话题排在最前时传入 "pinned"
+// 用法:
+//         query := database.DB.Model(Topic{})
+//         var topics []Topic
+//         page, err := paginator.CursorPaginate(c, query, &topics, perPage)
+func CursorPaginate(c *gin.Context, db *gorm.DB, data interface{}, perPage int, leading ...string) (CursorPage, error) {
+	// 经 middlewares.WithTrashed 校验过权限的 with_trashed 请求，包含回收站中的数据
+	if c.GetBool("with_trashed") {
+		db = db.Unscoped()
+	}
+
+	p := &Paginator{ctx: c}
+	p.PerPage = p.getPerPage(perPage)
+
+	// 1. 解析游标，首页使用请求参数中的排序规则
+	cur := cursor{}
+	if raw := c.Query("cursor"); len(raw) > 0 {
+		var err error
+		if cur, err = decodeCursor(raw); err != nil {
+			return CursorPage{}, err
+		}
+	} else {
+		cur.Sort, cur.Order = cursorSort(
+			c.DefaultQuery(config.Get("page.url_query_sort"), "id"),
+			c.DefaultQuery(config.Get("page.url_query_order"), "asc"),
+		)
+	}
+
+	// 2. 解析模型，获取排序字段
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(data); err != nil {
+		return CursorPage{}, err
+	}
+	sortField := stmt.Schema.LookUpField(cur.Sort)
+	idField := stmt.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return CursorPage{}, ErrInvalidCursor
+	}
+	// 游标只能用于生成它的列表，前置排序字段的数量必须一致
+	if len(cur.Value) > 0 && len(cur.Leading) != len(leading) {
+		return CursorPage{}, ErrInvalidCursor
+	}
+
+	// 3. 按前置字段、排序字段、id 的顺序比较，向前翻页时反向查询，查询结果再倒序
+	var keys []cursorKey
+	for i, name := range leading {
+		field := stmt.Schema.LookUpField(name)
+		if field == nil {
+			return CursorPage{}, ErrInvalidCursor
+		}
+		key := cursorKey{field: field, order: "desc"}
+		if len(cur.Value) > 0 {
+			value, err := unmarshalCursorValue(field, cur.Leading[i])
+			if err != nil {
+				return CursorPage{}, err
+			}
+			key.value = value
+		}
+		keys = append(keys, key)
+	}
+	sortKey := cursorKey{field: sortField, order: cur.Order}
+	if len(cur.Value) > 0 {
+		value, err := unmarshalCursorValue(sortField, cur.Value)
+		if err != nil {
+			return CursorPage{}, err
+		}
+		sortKey.value = value
+	}
+	keys = append(keys, sortKey)
+	if cur.Sort != "id" {
+		keys = append(keys, cursorKey{field: idField, order: cur.Order, value: cur.ID})
+	} else {
+		keys[len(keys)-1].value = cur.ID
+	}
+	if cur.Backward {
+		for i := range keys {
+			keys[i].order = reverseOrder(keys[i].order)
+		}
+	}
+
+	query := db
+	if len(cur.Value) > 0 {
+		condition, args := keysetCondition(keys)
+		query = query.Where(condition, args...)
+	}
+	orders := make([]string, 0, len(keys))
+	for _, key := range keys {
+		orders = append(orders, key.field.DBName+" "+key.order)
+	}
+	orderBy := strings.Join(orders, ", ")
+
+	// 多取一条，判断是否还有更多数据
+	err := query.Preload(clause.Associations).
+		Order(orderBy).
+		Limit(p.PerPage + 1).
+		Find(data).
+		Error
+	if err != nil {
+		logger.LogIf(err)
+		return CursorPage{}, err
+	}
+
+	rows := reflect.ValueOf(data).Elem()
+	hasMore := rows.Len() > p.PerPage
+	if hasMore {
+		rows.Set(rows.Slice(0, p.PerPage))
+	}
+	if cur.Backward {
+		for i, k := 0, rows.Len()-1; i < k; i, k = i+1, k-1 {
+			tmp := reflect.ValueOf(rows.Index(i).Interface())
+			rows.Index(i).Set(rows.Index(k))
+			rows.Index(k).Set(tmp)
+		}
+	}
+
+	// 4. 生成上一页和下一页的游标
+	page := CursorPage{PerPage: p.PerPage}
+	if rows.Len() == 0 {
+		return page, nil
+	}
+	hasNext, hasPrev := hasMore, len(cur.Value) > 0
+	if cur.Backward {
+		hasNext, hasPrev = len(cur.Value) > 0, hasMore
+	}
+	makeCursor := func(row reflect.Value, backward bool) string {
+		sortValue, _ := sortField.ValueOf(row)
+		idValue, _ := idField.ValueOf(row)
+		value, _ := json.Marshal(sortValue)
+		id, _ := idValue.(uint64)
+		next := cursor{Sort: cur.Sort, Order: cur.Order, Value: value, ID: id, Backward: backward}
+		for _, key := range keys[:len(leading)] {
+			leadingValue, _ := key.field.ValueOf(row)
+			value, _ := json.Marshal(leadingValue)
+			next.Leading = append(next.Leading, value)
+		}
+		return encodeCursor(next)
+	}
+	if hasNext {
+		page.NextCursor = makeCursor(reflect.Indirect(rows.Index(rows.Len()-1)), false)
+	}
+	if hasPrev {
+		page.PrevCursor = makeCursor(reflect.Indirect(rows.Index(0)), true)
+	}
+	return page, nil
+}
+
+// keysetCondition 生成 keyset 比较条件，如 (a < ?) OR (a = ? AND ((b > ?) OR (b = ? AND id > ?)))
+func keysetCondition(keys []cursorKey) (string, []interface{}) {
+	key := keys[0]
+	op := ">"
+	if key.order == "desc" {
+		op = "<"
+	}
+	if len(keys) == 1 {
+		return key.field.DBName + " " + op + " ?", []interface{}{key.value}
+	}
+	rest, args := keysetCondition(keys[1:])
+	condition := "(" + key.field.DBName + " " + op + " ?) OR (" + key.field.DBName + " = ? AND (" + rest + "))"
+	return condition, append([]interface{}{key.value, key.value}, args...)
+}
+
+// unmarshalCursorValue 按字段类型解析游标中的值
+func unmarshalCursorValue(field *schema.Field, raw json.RawMessage) (interface{}, error) {
+	value := reflect.New(field.FieldType)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return value.Elem().Interface(), nil
+}
+
+// cursorSort 游标分页只使用 sort 参数的第一个字段
+func cursorSort(sort, order string) (string, string) {
+	field := strings.TrimSpace(strings.Split(sort, ",")[0])
+	if strings.HasPrefix(field, "-") {
+		field, order = field[1:], "desc"
+	}
+	if order != "desc" {
+		order = "asc"
+	}
+	return field, order
+}
+
+// reverseOrder 反转排序规则
+func reverseOrder(order string) string {
+	if order == "desc" {
+		return "asc"
+	}
+	return "desc"
+}
+
+// encodeCursor 游标编码为 base64(JSON).base64(签名)，签名防止客户端篡改排序字段和值
+func encodeCursor(cur cursor) string {
+	payload, _ := json.Marshal(cur)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + cursorSignature(encoded)
+}
+
+// decodeCursor 校验签名并解析游标
+func decodeCursor(raw string) (cursor, error) {
+	var cur cursor
+	parts := strings.Split(raw, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(cursorSignature(parts[0]))) {
+		return cur, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return cur, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, &cur); err != nil || len(cur.Sort) == 0 {
+		return cur, ErrInvalidCursor
+	}
+	// 排序字段会拼接到 SQL 中，签名之外再校验一次字符
+	if strings.Trim(cur.Sort, "abcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+		return cur, ErrInvalidCursor
+	}
+	return cur, nil
+}
+
+// cursorSignature 使用 app.key 计算签名，截取前 16 字节
+func cursorSignature(encoded string) string {
+	mac := hmac.New(sha256.New, []byte(config.GetString("app.key")))
+	mac.Write([]byte("cursor:" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
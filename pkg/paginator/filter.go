@@ -0,0 +1,77 @@
+package paginator
+
+import (
+	"gohub/pkg/app"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Filter 列表的过滤条件，将 URL 参数映射为查询条件
+// 参数值的格式需在请求验证中校验，如 category_id 使用 numeric 规则
+type Filter struct {
+	Param    string // URL 参数名称，如 created_after
+	Column   string // 数据表字段，为空时与 Param 相同
+	Operator string // 支持 =、!=、>、>=、<、<=、like 和 in（逗号分隔的多个值），为空时为 =
+	Date     bool   // 值为 2006-01-02 或 2006/01/02 格式的日期，按应用时区转换为当天零点
+}
+
+// Filters 某个模型允许过滤的字段白名单，在模型的 util 文件中声明，如：
+//         var Filters = paginator.Filters{
+//             {Param: "category_id"},
+//             {Param: "created_after", Column: "created_at", Operator: ">=", Date: true},
+//         }
+type Filters []Filter
+
+// operators 支持的运算符
+var operators = map[string]string{
+	"=":    "= ?",
+	"!=":   "<> ?",
+	">":    "> ?",
+	">=":   ">= ?",
+	"<":    "< ?",
+	"<=":   "<= ?",
+	"like": "LIKE ?",
+	"in":   "IN ?",
+}
+
+// Apply 根据 URL 参数为查询句柄添加条件，未传参的过滤条件忽略
+func (filters Filters) Apply(c *gin.Context, db *gorm.DB) *gorm.DB {
+	for _, f := range filters {
+		value, ok := c.GetQuery(f.Param)
+		if !ok || len(value) == 0 {
+			continue
+		}
+
+		column := f.Column
+		if len(column) == 0 {
+			column = f.Param
+		}
+		operator := f.Operator
+		if len(operator) == 0 {
+			operator = "="
+		}
+		expr, ok := operators[operator]
+		if !ok {
+			continue
+		}
+
+		var arg interface{} = value
+		switch {
+		case operator == "in":
+			arg = strings.Split(value, ",")
+		case operator == "like":
+			arg = "%" + value + "%"
+		case f.Date:
+			t, err := time.ParseInLocation("2006-01-02", strings.ReplaceAll(value, "/", "-"), app.TimenowInTimezone().Location())
+			if err != nil {
+				continue
+			}
+			arg = t
+		}
+		db = db.Where(column+" "+expr, arg)
+	}
+	return db
+}
@@ -53,6 +53,11 @@ type Paginator struct {
 //             perPage,
 //         )
 func Paginate(c *gin.Context, db *gorm.DB, data interface{}, baseURL string, perPage int) Page {
+	// 经 middlewares.WithTrashed 校验过权限的 with_trashed 请求，包含回收站中的数据
+	if c.GetBool("with_trashed") {
+		db = db.Unscoped()
+	}
+
 	// 初始化 Paginator 实例
 	p := &Paginator{
 		query: db,
@@ -62,7 +67,7 @@ func Paginate(c *gin.Context, db *gorm.DB, data interface{}, baseURL string, per
 
 	// 查询数据库
 	err := p.query.Preload(clause.Associations). // 读取关联
-							Order(p.Sort + " " + p.Order).
+							Order(p.orderClause()).
 							Limit(p.PerPage).
 							Offset(p.Offset).
 							Find(data).
@@ -82,6 +87,30 @@ func Paginate(c *gin.Context, db *gorm.DB, data interface{}, baseURL string, per
 	}
 }
 
+// PaginateTotal 数据不是通过 GORM 查询时（如搜索引擎）使用，传参总条数计算分页信息
+// 返回分页信息，以及读取数据时使用的 offset 和 limit
+func PaginateTotal(c *gin.Context, total int64, baseURL string, perPage int) (page Page, offset, limit int) {
+	p := &Paginator{
+		ctx:        c,
+		TotalCount: total,
+	}
+	p.initProperties(perPage, baseURL)
+
+	// 没有数据时 CurrentPage 为 0
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+
+	return Page{
+		CurrentPage: p.CurrentPage,
+		PerPage:     p.PerPage,
+		TotalPage:   p.TotalPage,
+		TotalCount:  p.TotalCount,
+		NextPageUrl: p.getNextPageURL(),
+		PrevPageUrl: p.getPrevPageURL(),
+	}, p.Offset, p.PerPage
+}
+
 // initProperties 初始化分页必须用到的属性，基于这些属性查询数据库
 func (p *Paginator) initProperties(perPage int, baseURL string) {
 	p.BaseURL = p.formatBaseURL(baseURL)
@@ -91,7 +120,10 @@ func (p *Paginator) initProperties(perPage int, baseURL string) {
 	p.Order = p.ctx.DefaultQuery(config.Get("page.url_query_order"), "asc")
 	p.Sort = p.ctx.DefaultQuery(config.Get("page.url_query_sort"), "id")
 
-	p.TotalCount = p.getTotalCount()
+	// 未传参查询句柄时，使用 PaginateTotal 传参的总条数
+	if p.query != nil {
+		p.TotalCount = p.getTotalCount()
+	}
 	p.TotalPage = p.getTotalPage()
 	p.CurrentPage = p.getCurrentPage()
 	p.Offset = (p.CurrentPage - 1) * p.PerPage
@@ -150,8 +182,46 @@ func (p *Paginator) getTotalPage() int {
 	return int(nums)
 }
 
-// formatBaseURL 兼容 URL 带与不带 `?` 的情况
+// orderClause 排序语句，sort 参数支持逗号分隔的多个字段，字段前加 - 表示倒序，
+// 如 sort=-reply_count,created_at；不带 - 的字段使用 order 参数的排序规则，兼容 sort=id&order=desc
+func (p *Paginator) orderClause() string {
+	var clauses []string
+	for _, field := range strings.Split(p.Sort, ",") {
+		field = strings.TrimSpace(field)
+		order := p.Order
+		if strings.HasPrefix(field, "-") {
+			field, order = field[1:], "desc"
+		}
+		// 控制器中已验证过字段白名单，这里再过滤一次非法字符，避免 SQL 注入
+		if len(field) == 0 || strings.Trim(field, "abcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+			continue
+		}
+		if order != "desc" {
+			order = "asc"
+		}
+		clauses = append(clauses, field+" "+order)
+	}
+	if len(clauses) == 0 {
+		return "id asc"
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// formatBaseURL 兼容 URL 带与不带 `?` 的情况，并保留分页参数以外的其他参数（如过滤条件）
 func (p *Paginator) formatBaseURL(baseURL string) string {
+	query := p.ctx.Request.URL.Query()
+	for _, key := range []string{"page.url_query_page", "page.url_query_sort", "page.url_query_order", "page.url_query_per_page"} {
+		query.Del(config.Get(key))
+	}
+	if len(query) > 0 {
+		if strings.Contains(baseURL, "?") {
+			baseURL += "&"
+		} else {
+			baseURL += "?"
+		}
+		baseURL += query.Encode()
+	}
+
 	if strings.Contains(baseURL, "?") {
 		baseURL += "&"
 	} else {
@@ -185,7 +255,7 @@ func (p *Paginator) getNextPageURL() string {
 
 // getPrevPageURL 返回下一页的链接
 func (p *Paginator) getPrevPageURL() string {
-	if p.CurrentPage == 1 || p.CurrentPage > p.TotalPage {
+	if p.CurrentPage <= 1 || p.CurrentPage > p.TotalPage {
 		return ""
 	}
 	return p.getPageLink(p.CurrentPage - 1)
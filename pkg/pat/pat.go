@@ -0,0 +1,91 @@
+// Package pat 个人访问令牌（Personal Access Token）的生成、识别和作用域
+package pat
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ScopeRead 只能发起 GET 等只读请求
+	ScopeRead = "read"
+	// ScopeWrite 允许创建、修改和删除
+	ScopeWrite = "write"
+)
+
+// Scopes 所有可用的作用域及说明
+var Scopes = map[string]string{
+	ScopeRead:  "读取数据",
+	ScopeWrite: "创建、修改和删除数据",
+}
+
+// randomLength 令牌随机部分的长度，62^40 约等于 238 位熵
+const randomLength = 40
+
+// displayLength 令牌列表中展示的随机部分的长度
+const displayLength = 6
+
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Generate 生成新的令牌，返回明文（只展示一次）、用于展示的前缀和保存到数据库的哈希值
+func Generate() (plain, prefix, hashed string) {
+	random := make([]byte, 0, randomLength)
+	buf := make([]byte, randomLength)
+	for len(random) < randomLength {
+		if _, err := rand.Read(buf); err != nil {
+			logger.LogIf(err)
+			continue
+		}
+		for _, b := range buf {
+			// 丢弃 248 及以上的值，保证每个字符出现的概率相同
+			if b < 248 && len(random) < randomLength {
+				random = append(random, alphabet[b%62])
+			}
+		}
+	}
+
+	plain = Prefix() + string(random)
+	return plain, plain[:len(Prefix())+displayLength], Hash(plain)
+}
+
+// Hash 令牌本身是高熵随机串，使用 SHA-256 即可，便于按哈希值直接查询
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// Prefix 令牌的固定前缀
+func Prefix() string {
+	return config.GetString("pat.prefix", "gohub_pat_")
+}
+
+// IsPAT 根据前缀判断是否为个人访问令牌
+func IsPAT(token string) bool {
+	return strings.HasPrefix(token, Prefix())
+}
+
+// FromHeader 从标头 Authorization:Bearer xxxxx 中获取个人访问令牌，不是个人访问令牌时返回空字符串
+func FromHeader(c *gin.Context) string {
+	parts := strings.SplitN(c.Request.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" || !IsPAT(parts[1]) {
+		return ""
+	}
+	return parts[1]
+}
+
+// ScopeNames 所有作用域的名称
+func ScopeNames() []string {
+	names := make([]string, 0, len(Scopes))
+	for name := range Scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
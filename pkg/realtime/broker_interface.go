@@ -0,0 +1,11 @@
+package realtime
+
+import "context"
+
+type Broker interface {
+	// Publish 发布事件，所有服务器实例都会收到
+	Publish(payload []byte) error
+
+	// Subscribe 接收事件，阻塞直到 ctx 结束或连接出错
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
@@ -0,0 +1,39 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker 实现 realtime.Broker interface，进程内分发，只适用于单个服务器实例
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers []func(payload []byte)
+}
+
+var _ Broker = (*MemoryBroker)(nil)
+
+// Publish 实现 realtime.Broker interface 的 Publish 方法
+func (b *MemoryBroker) Publish(payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+// Subscribe 实现 realtime.Broker interface 的 Subscribe 方法
+func (b *MemoryBroker) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	index := len(b.handlers) - 1
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	b.handlers[index] = func([]byte) {}
+	b.mu.Unlock()
+	return nil
+}
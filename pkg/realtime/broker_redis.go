@@ -0,0 +1,42 @@
+package realtime
+
+import (
+	"context"
+	"gohub/pkg/redis"
+)
+
+// RedisBroker 实现 realtime.Broker interface，基于 Redis 发布订阅，用于多个服务器实例
+type RedisBroker struct {
+	RedisClient *redis.RedisClient
+	Channel     string
+}
+
+var _ Broker = (*RedisBroker)(nil)
+
+// Publish 实现 realtime.Broker interface 的 Publish 方法
+func (b *RedisBroker) Publish(payload []byte) error {
+	return b.RedisClient.Client.Publish(b.RedisClient.Context, b.Channel, payload).Err()
+}
+
+// Subscribe 实现 realtime.Broker interface 的 Subscribe 方法
+func (b *RedisBroker) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	pubsub := b.RedisClient.Client.Subscribe(ctx, b.Channel)
+	defer pubsub.Close()
+
+	// 等待订阅成功，连接出错时返回，由 Hub.Run 重连
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handler([]byte(message.Payload))
+		}
+	}
+}
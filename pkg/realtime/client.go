@@ -0,0 +1,50 @@
+package realtime
+
+import "sync"
+
+// Client 一个 WebSocket 或 SSE 连接
+type Client struct {
+	UserID string
+
+	events   chan Event
+	done     chan struct{}
+	once     sync.Once
+	channels map[string]bool // 由 Hub.mu 保护
+}
+
+// NewClient 创建连接，buffer 为待推送事件的缓冲数量
+func NewClient(userID string, buffer int) *Client {
+	return &Client{
+		UserID:   userID,
+		events:   make(chan Event, buffer),
+		done:     make(chan struct{}),
+		channels: make(map[string]bool),
+	}
+}
+
+// Events 待推送的事件
+func (client *Client) Events() <-chan Event {
+	return client.events
+}
+
+// Done 连接关闭时关闭
+func (client *Client) Done() <-chan struct{} {
+	return client.done
+}
+
+// Close 关闭连接，可重复调用
+func (client *Client) Close() {
+	client.once.Do(func() {
+		close(client.done)
+	})
+}
+
+// send 推送事件，客户端处理太慢、缓冲已满时断开连接，避免拖慢其他客户端，客户端重连即可
+func (client *Client) send(event Event) {
+	select {
+	case client.events <- event:
+	case <-client.done:
+	default:
+		client.Close()
+	}
+}
@@ -0,0 +1,168 @@
+// Package realtime 实时推送，客户端通过 WebSocket 或 SSE（Server-Sent Events）订阅频道，
+// 事件经 Broker（Redis 发布订阅）分发到所有服务器实例，再由各实例推送给本机的连接
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChannelTopics 所有新话题的频道
+const ChannelTopics = "topics"
+
+// TopicChannel 话题的频道，推送话题下的新回复
+func TopicChannel(topicID string) string {
+	return "topic." + topicID
+}
+
+// UserChannel 用户的私有频道，推送通知等，只有用户本人可以订阅
+func UserChannel(userID string) string {
+	return "user." + userID
+}
+
+// Event 推送给客户端的事件
+type Event struct {
+	Channel string          `json:"channel"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Time    int64           `json:"time"`
+}
+
+// Hub 管理本机的所有连接和订阅关系
+type Hub struct {
+	Broker Broker
+
+	mu          sync.RWMutex
+	subscribers map[string]map[*Client]bool
+}
+
+// once 确保 internalHub 对象只初始化一次
+var once sync.Once
+
+// internalHub 内部使用的 Hub 对象
+var internalHub *Hub
+
+// NewHub 单例模式获取
+func NewHub() *Hub {
+	once.Do(func() {
+		var broker Broker = &MemoryBroker{}
+		if config.GetString("realtime.broker") == "redis" {
+			broker = &RedisBroker{
+				RedisClient: redis.Redis,
+				Channel:     config.GetString("app.name") + ":realtime",
+			}
+		}
+		internalHub = &Hub{
+			Broker:      broker,
+			subscribers: make(map[string]map[*Client]bool),
+		}
+	})
+	return internalHub
+}
+
+// Run 接收 Broker 的事件并推送给本机的订阅者，在 serve 命令中以 goroutine 运行，直到 ctx 结束
+// 与 Broker 的连接断开时自动重连
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.Broker.Subscribe(ctx, h.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.LogIf(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Publish 发布事件，data 序列化为 JSON 推送给订阅了 channel 的客户端，调用示例：
+//         realtime.NewHub().Publish(realtime.TopicChannel(topicID), "reply.created", replyModel)
+// 不在 serve 进程中调用（如命令行）时，经 Redis 同样能推送到客户端
+func (h *Hub) Publish(channel, eventType string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(Event{
+		Channel: channel,
+		Type:    eventType,
+		Data:    raw,
+		Time:    time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return h.Broker.Publish(payload)
+}
+
+// Subscribe 订阅频道，订阅前由调用方检查权限
+func (h *Hub) Subscribe(client *Client, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[channel] == nil {
+		h.subscribers[channel] = make(map[*Client]bool)
+	}
+	h.subscribers[channel][client] = true
+	client.channels[channel] = true
+}
+
+// Unsubscribe 取消订阅频道
+func (h *Hub) Unsubscribe(client *Client, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.unsubscribe(client, channel)
+}
+
+// Remove 连接断开时取消所有订阅
+func (h *Hub) Remove(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for channel := range client.channels {
+		h.unsubscribe(client, channel)
+	}
+	client.Close()
+}
+
+func (h *Hub) unsubscribe(client *Client, channel string) {
+	delete(client.channels, channel)
+	if subscribers, ok := h.subscribers[channel]; ok {
+		delete(subscribers, client)
+		if len(subscribers) == 0 {
+			delete(h.subscribers, channel)
+		}
+	}
+}
+
+// dispatch 将 Broker 收到的事件推送给本机订阅了该频道的客户端
+func (h *Hub) dispatch(payload []byte) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		logger.LogIf(err)
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for client := range h.subscribers[event.Channel] {
+		client.send(event)
+	}
+}
+
+// ParseChannels 解析逗号分隔的频道列表，如 topics,topic.1,user.2
+func ParseChannels(channels string) []string {
+	var result []string
+	for _, channel := range strings.Split(channels, ",") {
+		if channel = strings.TrimSpace(channel); len(channel) > 0 {
+			result = append(result, channel)
+		}
+	}
+	return result
+}
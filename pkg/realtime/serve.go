@@ -0,0 +1,164 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Authorizer 判断当前用户能否订阅频道
+type Authorizer func(channel string) bool
+
+// Command 客户端通过 WebSocket 发送的指令，如 {"action":"subscribe","channel":"topic.1"}
+type Command struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+}
+
+// ServeWebSocket 处理 WebSocket 连接，channels 为连接时订阅的频道（调用方已检查权限），
+// 连接后客户端可发送 Command 订阅或取消订阅其他频道，由 authorize 检查权限
+func (h *Hub) ServeWebSocket(c *gin.Context, userID string, channels []string, authorize Authorizer) {
+	pingInterval := time.Duration(config.GetInt("realtime.ping_interval")) * time.Second
+	ws, err := upgrade(c.Writer, c.Request, config.GetInt64("realtime.max_message"), pingInterval*2)
+	if err != nil {
+		// 握手失败的响应已由 upgrader.Error 写入
+		c.Abort()
+		return
+	}
+	defer ws.Close()
+
+	client := NewClient(userID, config.GetInt("realtime.buffer"))
+	defer h.Remove(client)
+	for _, channel := range channels {
+		h.Subscribe(client, channel)
+	}
+	client.send(Event{Type: "connected", Data: mustJSON(gin.H{"channels": channels}), Time: time.Now().Unix()})
+
+	// 读取客户端的指令，连接断开时关闭 client，结束下面的推送循环
+	go func() {
+		defer client.Close()
+		for {
+			messageType, message, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType == websocket.TextMessage {
+				h.handleCommand(client, message, authorize)
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-client.Events():
+			payload, _ := json.Marshal(event)
+			if err := writeMessage(ws, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := writeControl(ws, websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-client.Done():
+			writeControl(ws, websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+// ServeSSE 处理 SSE 连接，供不支持 WebSocket 的客户端使用，只能在连接时通过 channels 订阅
+func (h *Hub) ServeSSE(c *gin.Context, userID string, channels []string) {
+	client := NewClient(userID, config.GetInt("realtime.buffer"))
+	defer h.Remove(client)
+	for _, channel := range channels {
+		h.Subscribe(client, channel)
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// 避免 Nginx 缓冲响应
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// 断线后浏览器 3 秒后自动重连
+	fmt.Fprint(c.Writer, "retry: 3000\n\n")
+	writeSSE(c, Event{Type: "connected", Data: mustJSON(gin.H{"channels": channels}), Time: time.Now().Unix()})
+
+	ticker := time.NewTicker(time.Duration(config.GetInt("realtime.ping_interval")) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-client.Events():
+			writeSSE(c, event)
+		case <-ticker.C:
+			// 注释行作为心跳，保持连接不被代理服务器断开
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case <-client.Done():
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// handleCommand 处理客户端的订阅指令，结果以 subscribed、unsubscribed 或 error 事件返回
+func (h *Hub) handleCommand(client *Client, message []byte, authorize Authorizer) {
+	var command Command
+	if err := json.Unmarshal(message, &command); err != nil {
+		client.send(errorEvent(command.Channel, "指令格式错误"))
+		return
+	}
+
+	switch command.Action {
+	case "subscribe":
+		if !authorize(command.Channel) {
+			client.send(errorEvent(command.Channel, "无权订阅此频道"))
+			return
+		}
+		if h.channelCount(client) >= config.GetInt("realtime.max_channels") {
+			client.send(errorEvent(command.Channel, "订阅的频道过多"))
+			return
+		}
+		h.Subscribe(client, command.Channel)
+		client.send(Event{Channel: command.Channel, Type: "subscribed", Time: time.Now().Unix()})
+	case "unsubscribe":
+		h.Unsubscribe(client, command.Channel)
+		client.send(Event{Channel: command.Channel, Type: "unsubscribed", Time: time.Now().Unix()})
+	default:
+		client.send(errorEvent(command.Channel, "不支持的指令 "+command.Action))
+	}
+}
+
+// channelCount 客户端已订阅的频道数
+func (h *Hub) channelCount(client *Client) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(client.channels)
+}
+
+func writeSSE(c *gin.Context, event Event) {
+	payload, _ := json.Marshal(event)
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, payload)
+	c.Writer.Flush()
+}
+
+func errorEvent(channel, message string) Event {
+	return Event{Channel: channel, Type: "error", Data: mustJSON(gin.H{"message": message}), Time: time.Now().Unix()}
+}
+
+func mustJSON(data interface{}) json.RawMessage {
+	raw, err := json.Marshal(data)
+	logger.LogIf(err)
+	return raw
+}
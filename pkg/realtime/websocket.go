@@ -0,0 +1,57 @@
+package realtime
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// writeWait 发送一条消息的超时时间
+const writeWait = 10 * time.Second
+
+// upgrader WebSocket 握手，帧的编解码、ping/pong 和关闭握手由 gorilla/websocket 处理
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// 令牌通过标头或 ?token= 传递，不依赖 Cookie，不存在跨站 WebSocket 劫持的问题，允许任意来源
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+	Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(gin.H{
+			"message": "WebSocket 握手失败，不支持 WebSocket 的客户端请使用 SSE",
+			"error":   reason.Error(),
+		})
+	},
+}
+
+// upgrade 完成握手，超过 readTimeout 没有收到任何消息（包括 pong）时视为连接已断开
+func upgrade(w http.ResponseWriter, r *http.Request, maxMessage int64, readTimeout time.Duration) (*websocket.Conn, error) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.SetReadLimit(maxMessage)
+	ws.SetReadDeadline(time.Now().Add(readTimeout))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+	return ws, nil
+}
+
+// writeMessage 发送一条文本消息
+func writeMessage(ws *websocket.Conn, payload []byte) error {
+	ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return ws.WriteMessage(websocket.TextMessage, payload)
+}
+
+// writeControl 发送 ping 或关闭等控制帧，可与 writeMessage 并发调用
+func writeControl(ws *websocket.Conn, messageType int, data []byte) error {
+	return ws.WriteControl(messageType, data, time.Now().Add(writeWait))
+}
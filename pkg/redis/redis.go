@@ -65,6 +65,17 @@ func (rds RedisClient) Set(key string, value interface{}, expiration time.Durati
 	return true
 }
 
+// SetNX 仅当 key 不存在时存储 value，设置成功返回 true，key 已存在返回 false
+// 出错时返回 false 和 err，调用方可以区分 key 已存在和 Redis 不可用
+func (rds RedisClient) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	ok, err := rds.Client.SetNX(rds.Context, key, value, expiration).Result()
+	if err != nil {
+		logger.ErrorString("Redis", "SetNX", err.Error())
+		return false, err
+	}
+	return ok, nil
+}
+
 // Get 获取 key 对应的 value
 func (rds RedisClient) Get(key string) string {
 	result, err := rds.Client.Get(rds.Context, key).Result()
@@ -98,6 +109,55 @@ func (rds RedisClient) Del(key string) bool {
 	return true
 }
 
+// Expire 设置 key 的过期时间
+func (rds RedisClient) Expire(key string, expiration time.Duration) bool {
+	if err := rds.Client.Expire(rds.Context, key, expiration).Err(); err != nil {
+		logger.ErrorString("Redis", "Expire", err.Error())
+		return false
+	}
+	return true
+}
+
+// HSet 存储哈希表 key 中 field 对应的 value
+func (rds RedisClient) HSet(key, field string, value interface{}) bool {
+	if err := rds.Client.HSet(rds.Context, key, field, value).Err(); err != nil {
+		logger.ErrorString("Redis", "HSet", err.Error())
+		return false
+	}
+	return true
+}
+
+// HGet 获取哈希表 key 中 field 对应的 value
+func (rds RedisClient) HGet(key, field string) string {
+	result, err := rds.Client.HGet(rds.Context, key, field).Result()
+	if err != nil {
+		if err != redis.Nil {
+			logger.ErrorString("Redis", "HGet", err.Error())
+		}
+		return ""
+	}
+	return result
+}
+
+// HGetAll 获取哈希表 key 中所有的 field 和 value
+func (rds RedisClient) HGetAll(key string) map[string]string {
+	result, err := rds.Client.HGetAll(rds.Context, key).Result()
+	if err != nil {
+		logger.ErrorString("Redis", "HGetAll", err.Error())
+		return map[string]string{}
+	}
+	return result
+}
+
+// HDel 删除哈希表 key 中的 field，支持多个 field 传参
+func (rds RedisClient) HDel(key string, fields ...string) bool {
+	if err := rds.Client.HDel(rds.Context, key, fields...).Err(); err != nil {
+		logger.ErrorString("Redis", "HDel", err.Error())
+		return false
+	}
+	return true
+}
+
 // FlushDB 清空当前 redis db 里的所有数据
 func (rds RedisClient) FlushDB() bool {
 	if err := rds.Client.FlushDB(rds.Context).Err(); err != nil {
@@ -107,6 +167,19 @@ func (rds RedisClient) FlushDB() bool {
 	return true
 }
 
+// IncrementWithExpire key 的值增加 1 并返回增加后的值，key 首次创建时设置过期时间，出错时返回 0
+func (rds RedisClient) IncrementWithExpire(key string, expiration time.Duration) int64 {
+	val, err := rds.Client.Incr(rds.Context, key).Result()
+	if err != nil {
+		logger.ErrorString("Redis", "IncrementWithExpire", err.Error())
+		return 0
+	}
+	if val == 1 {
+		rds.Expire(key, expiration)
+	}
+	return val
+}
+
 // Increment 当参数只有 1 个时，为 key，其值增加 1。
 // 当参数有 2 个时，第一个参数为 key ，第二个参数为要增加的值 int64 类型。
 func (rds RedisClient) Increment(parameters ...interface{}) bool {
@@ -66,6 +66,11 @@ func Abort500(c *gin.Context, msg ...string) {
 	})
 }
 
+// AbortJSON 中断请求，使用指定的状态码响应 JSON，用于需要附带额外字段的错误响应
+func AbortJSON(c *gin.Context, code int, data gin.H) {
+	c.AbortWithStatusJSON(code, data)
+}
+
 // BadRequest 响应 400，传参 err 对象，未传参 msg 时使用默认消息
 // 在解析用户请求，请求的格式或者方法不符合预期时调用
 func BadRequest(c *gin.Context, err error, msg ...string) {
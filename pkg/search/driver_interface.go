@@ -0,0 +1,24 @@
+package search
+
+import "gorm.io/gorm"
+
+// Driver 搜索驱动，db 为数据库驱动使用的查询句柄，memory 驱动会忽略
+type Driver interface {
+	// Migrate 创建索引需要的数据表，已存在时不做处理
+	Migrate(db *gorm.DB) error
+
+	// Index 写入文档，已存在则覆盖
+	Index(db *gorm.DB, docs []Document) error
+
+	// Delete 删除文档
+	Delete(db *gorm.DB, docType, id string) error
+
+	// Flush 清空某个类型的所有文档，docType 为空时清空所有
+	Flush(db *gorm.DB, docType string) error
+
+	// Count 符合条件的文档总数
+	Count(db *gorm.DB, q Query) (int64, error)
+
+	// Search 按相关度排序返回命中的文档
+	Search(db *gorm.DB, q Query) ([]Hit, error)
+}
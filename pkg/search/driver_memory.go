@@ -0,0 +1,268 @@
+package search
+
+import (
+	"encoding/gob"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Memory 进程内的倒排索引，使用 BM25 计算相关度，适合数据量不大或没有全文检索能力的数据库
+// 索引持久化到 Path 文件，文件被其他进程（如 search:reindex 命令）更新后自动重新加载
+type Memory struct {
+	Path string
+
+	mu       sync.RWMutex
+	index    *memoryIndex
+	loadedAt time.Time
+}
+
+var _ Driver = (*Memory)(nil)
+
+// memoryIndex 持久化的索引数据，key 为 type:id
+type memoryIndex struct {
+	Docs     map[string]memoryDoc
+	Postings map[string]map[string]int // 词 => 文档 => 词频
+}
+
+// memoryDoc 索引中的文档，不保存原文
+type memoryDoc struct {
+	Type       string
+	ID         string
+	CategoryID string
+	UserID     string
+	CreatedAt  int64
+	Terms      []string // 去重后的词，删除文档时使用
+	Length     int      // 总词数
+}
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Migrate 实现 search.Driver interface 的 Migrate 方法，memory 驱动不需要数据表
+func (m *Memory) Migrate(db *gorm.DB) error {
+	return nil
+}
+
+// Index 实现 search.Driver interface 的 Index 方法
+func (m *Memory) Index(db *gorm.DB, docs []Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load()
+
+	for _, doc := range docs {
+		key := doc.Type + ":" + doc.ID
+		m.remove(key)
+
+		tf := make(map[string]int)
+		terms := Tokenize(doc.Title + " " + doc.Body)
+		for _, term := range terms {
+			tf[term]++
+		}
+		entry := memoryDoc{
+			Type:       doc.Type,
+			ID:         doc.ID,
+			CategoryID: doc.CategoryID,
+			UserID:     doc.UserID,
+			CreatedAt:  doc.CreatedAt.Unix(),
+			Length:     len(terms),
+		}
+		for term, count := range tf {
+			if m.index.Postings[term] == nil {
+				m.index.Postings[term] = make(map[string]int)
+			}
+			m.index.Postings[term][key] = count
+			entry.Terms = append(entry.Terms, term)
+		}
+		m.index.Docs[key] = entry
+	}
+	return m.save()
+}
+
+// Delete 实现 search.Driver interface 的 Delete 方法
+func (m *Memory) Delete(db *gorm.DB, docType, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load()
+
+	key := docType + ":" + id
+	if _, ok := m.index.Docs[key]; !ok {
+		return nil
+	}
+	m.remove(key)
+	return m.save()
+}
+
+// Flush 实现 search.Driver interface 的 Flush 方法
+func (m *Memory) Flush(db *gorm.DB, docType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load()
+
+	for key, doc := range m.index.Docs {
+		if len(docType) == 0 || doc.Type == docType {
+			m.remove(key)
+		}
+	}
+	return m.save()
+}
+
+// Count 实现 search.Driver interface 的 Count 方法
+func (m *Memory) Count(db *gorm.DB, q Query) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load()
+
+	return int64(len(m.match(q))), nil
+}
+
+// Search 实现 search.Driver interface 的 Search 方法
+func (m *Memory) Search(db *gorm.DB, q Query) ([]Hit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.load()
+
+	keys := m.match(q)
+	terms := QueryTerms(q.Text)
+
+	// 平均文档长度
+	var totalLength int
+	for _, doc := range m.index.Docs {
+		totalLength += doc.Length
+	}
+	n := float64(len(m.index.Docs))
+	avgLength := float64(totalLength) / math.Max(n, 1)
+
+	hits := make([]Hit, 0, len(keys))
+	for _, key := range keys {
+		doc := m.index.Docs[key]
+		var score float64
+		for _, term := range terms {
+			df := float64(len(m.index.Postings[term]))
+			tf := float64(m.index.Postings[term][key])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(doc.Length)/avgLength))
+		}
+		hits = append(hits, Hit{Type: doc.Type, ID: doc.ID, Score: score})
+	}
+
+	// 相关度相同时，新的文档在前
+	sort.SliceStable(hits, func(i, k int) bool {
+		if hits[i].Score != hits[k].Score {
+			return hits[i].Score > hits[k].Score
+		}
+		return m.index.Docs[hits[i].Type+":"+hits[i].ID].CreatedAt > m.index.Docs[hits[k].Type+":"+hits[k].ID].CreatedAt
+	})
+
+	if q.Offset >= len(hits) {
+		return []Hit{}, nil
+	}
+	hits = hits[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(hits) {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+// match 包含所有查询词且符合过滤条件的文档
+func (m *Memory) match(q Query) (keys []string) {
+	terms := QueryTerms(q.Text)
+	if len(terms) == 0 {
+		return
+	}
+
+	// 从文档数最少的词开始取交集
+	sort.Slice(terms, func(i, k int) bool {
+		return len(m.index.Postings[terms[i]]) < len(m.index.Postings[terms[k]])
+	})
+	for key := range m.index.Postings[terms[0]] {
+		matched := matchFilters(m.index.Docs[key], q)
+		for _, term := range terms[1:] {
+			if !matched {
+				break
+			}
+			_, matched = m.index.Postings[term][key]
+		}
+		if matched {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// remove 从索引中删除文档，调用方需持有写锁
+func (m *Memory) remove(key string) {
+	doc, ok := m.index.Docs[key]
+	if !ok {
+		return
+	}
+	for _, term := range doc.Terms {
+		delete(m.index.Postings[term], key)
+		if len(m.index.Postings[term]) == 0 {
+			delete(m.index.Postings, term)
+		}
+	}
+	delete(m.index.Docs, key)
+}
+
+// load 首次使用或索引文件被其他进程更新时，从文件加载索引，调用方需持有写锁
+func (m *Memory) load() {
+	if len(m.Path) > 0 {
+		if info, err := os.Stat(m.Path); err == nil && info.ModTime().After(m.loadedAt) {
+			if f, err := os.Open(m.Path); err == nil {
+				var index memoryIndex
+				if err := gob.NewDecoder(f).Decode(&index); err == nil {
+					m.index = &index
+					m.loadedAt = info.ModTime()
+				}
+				f.Close()
+			}
+		}
+	}
+	if m.index == nil {
+		m.index = &memoryIndex{
+			Docs:     make(map[string]memoryDoc),
+			Postings: make(map[string]map[string]int),
+		}
+	}
+}
+
+// save 将索引写入文件，先写临时文件再重命名，避免其他进程读到写了一半的文件
+func (m *Memory) save() error {
+	if len(m.Path) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(m.Path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp := m.Path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(f).Encode(m.index); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, m.Path); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(m.Path); err == nil {
+		m.loadedAt = info.ModTime()
+	}
+	return nil
+}
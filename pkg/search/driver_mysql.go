@@ -0,0 +1,87 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/spf13/cast"
+	"gorm.io/gorm"
+)
+
+// MySQL 使用 MySQL FULLTEXT 索引，ngram 分词器支持中文（需 MySQL 5.7.6+）
+type MySQL struct {
+	Table string
+}
+
+var _ Driver = (*MySQL)(nil)
+
+// mysqlMatch 全文检索表达式，需传参搜索词
+const mysqlMatch = "MATCH(title, body) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+// Migrate 实现 search.Driver interface 的 Migrate 方法
+func (m *MySQL) Migrate(db *gorm.DB) error {
+	return db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` ("+
+		"`id` bigint unsigned NOT NULL AUTO_INCREMENT,"+
+		"`doc_type` varchar(20) NOT NULL,"+
+		"`doc_id` bigint unsigned NOT NULL,"+
+		"`title` varchar(255) NOT NULL DEFAULT '',"+
+		"`body` longtext,"+
+		"`category_id` bigint unsigned NOT NULL DEFAULT 0,"+
+		"`user_id` bigint unsigned NOT NULL DEFAULT 0,"+
+		"`created_at` bigint NOT NULL DEFAULT 0,"+
+		"PRIMARY KEY (`id`),"+
+		"UNIQUE KEY `uk_doc` (`doc_type`, `doc_id`),"+
+		"KEY `idx_category_id` (`category_id`),"+
+		"KEY `idx_user_id` (`user_id`),"+
+		"KEY `idx_created_at` (`created_at`),"+
+		"FULLTEXT KEY `ft_title_body` (`title`, `body`) WITH PARSER ngram"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", m.Table)).Error
+}
+
+// Index 实现 search.Driver interface 的 Index 方法
+func (m *MySQL) Index(db *gorm.DB, docs []Document) error {
+	for _, doc := range docs {
+		err := db.Exec(fmt.Sprintf("INSERT INTO `%s` (doc_type, doc_id, title, body, category_id, user_id, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE title = VALUES(title), body = VALUES(body), "+
+			"category_id = VALUES(category_id), user_id = VALUES(user_id), created_at = VALUES(created_at)", m.Table),
+			doc.Type, doc.ID, doc.Title, doc.Body,
+			cast.ToUint64(doc.CategoryID), cast.ToUint64(doc.UserID), doc.CreatedAt.Unix(),
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 实现 search.Driver interface 的 Delete 方法
+func (m *MySQL) Delete(db *gorm.DB, docType, id string) error {
+	return db.Table(m.Table).Where("doc_type = ? AND doc_id = ?", docType, id).Delete(nil).Error
+}
+
+// Flush 实现 search.Driver interface 的 Flush 方法
+func (m *MySQL) Flush(db *gorm.DB, docType string) error {
+	if len(docType) == 0 {
+		return db.Exec(fmt.Sprintf("TRUNCATE TABLE `%s`", m.Table)).Error
+	}
+	return db.Table(m.Table).Where("doc_type = ?", docType).Delete(nil).Error
+}
+
+// Count 实现 search.Driver interface 的 Count 方法
+func (m *MySQL) Count(db *gorm.DB, q Query) (count int64, err error) {
+	err = applyFilters(db.Table(m.Table).Where(mysqlMatch, q.Text), q).Count(&count).Error
+	return
+}
+
+// Search 实现 search.Driver interface 的 Search 方法
+func (m *MySQL) Search(db *gorm.DB, q Query) ([]Hit, error) {
+	hits := []Hit{}
+	err := applyFilters(db.Table(m.Table).Where(mysqlMatch, q.Text), q).
+		Select("doc_type AS type, doc_id AS id, "+mysqlMatch+" AS score", q.Text).
+		Order("score DESC, id DESC").
+		Offset(q.Offset).
+		Limit(q.Limit).
+		Scan(&hits).
+		Error
+	return hits, err
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SQLite 使用 SQLite FTS5 虚拟表，编译时需加上 -tags sqlite_fts5
+// FTS5 默认分词器不能切分中文，写入前先用 Tokenize 分词，以空格连接后保存在 terms 列
+type SQLite struct {
+	Table string
+}
+
+var _ Driver = (*SQLite)(nil)
+
+// Migrate 实现 search.Driver interface 的 Migrate 方法
+func (s *SQLite) Migrate(db *gorm.DB) error {
+	err := db.Exec(fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS `%s` USING fts5("+
+		"terms, doc_type UNINDEXED, doc_id UNINDEXED, category_id UNINDEXED, user_id UNINDEXED, created_at UNINDEXED)",
+		s.Table)).Error
+	if err != nil && strings.Contains(err.Error(), "no such module") {
+		return fmt.Errorf("search: sqlite FTS5 is not available, build with -tags sqlite_fts5: %w", err)
+	}
+	return err
+}
+
+// Index 实现 search.Driver interface 的 Index 方法
+func (s *SQLite) Index(db *gorm.DB, docs []Document) error {
+	for _, doc := range docs {
+		if err := s.Delete(db, doc.Type, doc.ID); err != nil {
+			return err
+		}
+		err := db.Exec(fmt.Sprintf("INSERT INTO `%s` (terms, doc_type, doc_id, category_id, user_id, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?)", s.Table),
+			strings.Join(Tokenize(doc.Title+" "+doc.Body), " "),
+			doc.Type, doc.ID, doc.CategoryID, doc.UserID, doc.CreatedAt.Unix(),
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 实现 search.Driver interface 的 Delete 方法
+func (s *SQLite) Delete(db *gorm.DB, docType, id string) error {
+	return db.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE doc_type = ? AND doc_id = ?", s.Table), docType, id).Error
+}
+
+// Flush 实现 search.Driver interface 的 Flush 方法
+func (s *SQLite) Flush(db *gorm.DB, docType string) error {
+	if len(docType) == 0 {
+		return db.Exec(fmt.Sprintf("DELETE FROM `%s`", s.Table)).Error
+	}
+	return db.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE doc_type = ?", s.Table), docType).Error
+}
+
+// Count 实现 search.Driver interface 的 Count 方法
+func (s *SQLite) Count(db *gorm.DB, q Query) (count int64, err error) {
+	err = applyFilters(db.Table(s.Table).Where(s.Table+" MATCH ?", s.matchExpr(q.Text)), q).Count(&count).Error
+	return
+}
+
+// Search 实现 search.Driver interface 的 Search 方法
+func (s *SQLite) Search(db *gorm.DB, q Query) ([]Hit, error) {
+	hits := []Hit{}
+	// bm25 的值越小越相关，取反作为得分
+	err := applyFilters(db.Table(s.Table).Where(s.Table+" MATCH ?", s.matchExpr(q.Text)), q).
+		Select("doc_type AS type, doc_id AS id, -bm25(" + s.Table + ") AS score").
+		Order("score DESC, rowid DESC").
+		Offset(q.Offset).
+		Limit(q.Limit).
+		Scan(&hits).
+		Error
+	return hits, err
+}
+
+// matchExpr FTS5 查询表达式，每个词加引号，以空格连接表示所有词都需匹配
+func (s *SQLite) matchExpr(text string) string {
+	terms := QueryTerms(text)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
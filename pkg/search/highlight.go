@@ -0,0 +1,96 @@
+package search
+
+import (
+	"gohub/pkg/config"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Highlight 截取 text 中第一处匹配附近的片段，匹配的词使用配置的标签包裹，其余内容做 HTML 转义
+// length 为片段的字符数，0 表示不截取
+func Highlight(text, query string, length int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// 1. 找出所有匹配的区间，合并重叠和相邻的区间（bigram 会互相重叠）
+	var spans [][2]int
+	for _, term := range QueryTerms(query) {
+		t := []rune(term)
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == term {
+				spans = append(spans, [2]int{i, i + len(t)})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, k int) bool { return spans[i][0] < spans[k][0] })
+	var merged [][2]int
+	for _, span := range spans {
+		if n := len(merged); n > 0 && span[0] <= merged[n-1][1] {
+			if span[1] > merged[n-1][1] {
+				merged[n-1][1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+
+	// 2. 计算片段范围，第一处匹配前保留四分之一的上下文
+	start, end := 0, len(runes)
+	if length > 0 && len(runes) > length {
+		if len(merged) > 0 {
+			start = merged[0][0] - length/4
+		}
+		if start < 0 {
+			start = 0
+		}
+		end = start + length
+		if end > len(runes) {
+			end = len(runes)
+			start = end - length
+		}
+	}
+
+	// 3. 拼接片段
+	preTag := config.GetString("search.highlight.pre_tag", "<em>")
+	postTag := config.GetString("search.highlight.post_tag", "</em>")
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	pos := start
+	for _, span := range merged {
+		if span[1] <= start || span[0] >= end {
+			continue
+		}
+		from, to := max(span[0], start), min(span[1], end)
+		b.WriteString(html.EscapeString(string(runes[pos:from])))
+		b.WriteString(preTag)
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString(postTag)
+		pos = to
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
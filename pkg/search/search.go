@@ -0,0 +1,167 @@
+// Package search 全文搜索，索引话题和用户，支持 MySQL FULLTEXT、SQLite FTS5 和进程内索引
+package search
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 索引的文档类型
+const (
+	TypeTopic = "topic"
+	TypeUser  = "user"
+)
+
+// Document 写入索引的文档
+type Document struct {
+	Type       string
+	ID         string
+	Title      string
+	Body       string
+	CategoryID string
+	UserID     string
+	CreatedAt  time.Time
+}
+
+// Query 搜索条件，除 Text 外均为可选的过滤条件
+type Query struct {
+	Text       string
+	Type       string
+	CategoryID string
+	UserID     string
+	From       time.Time
+	To         time.Time
+
+	Offset int
+	Limit  int
+}
+
+// Hit 搜索命中的文档，按 Score 从高到低排列
+type Hit struct {
+	Type  string  `json:"type"`
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// Search 搜索服务
+type Search struct {
+	Driver Driver
+}
+
+// once 确保 internalSearch 对象只初始化一次
+var once sync.Once
+
+// internalSearch 内部使用的 Search 对象
+var internalSearch *Search
+
+// NewSearch 单例模式获取
+func NewSearch() *Search {
+	once.Do(func() {
+		internalSearch = &Search{
+			Driver: newDriver(config.GetString("search.driver")),
+		}
+	})
+	return internalSearch
+}
+
+// newDriver 根据配置创建搜索驱动
+func newDriver(name string) Driver {
+	if name == "database" {
+		name = config.GetString("database.connection")
+	}
+	table := config.GetString("search.table", "search_documents")
+	switch name {
+	case "mysql":
+		return &MySQL{Table: table}
+	case "sqlite":
+		return &SQLite{Table: table}
+	default:
+		return &Memory{Path: config.GetString("search.memory.path")}
+	}
+}
+
+// Index 写入或更新索引，在模型钩子中调用时传参 tx，其他情况传参 nil 使用 database.DB
+func (s *Search) Index(db *gorm.DB, docs ...Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return s.Driver.Index(session(db), docs)
+}
+
+// Delete 从索引中删除文档
+func (s *Search) Delete(db *gorm.DB, docType, id string) error {
+	return s.Driver.Delete(session(db), docType, id)
+}
+
+// Flush 清空某个类型的所有文档，docType 为空时清空整个索引
+func (s *Search) Flush(db *gorm.DB, docType string) error {
+	return s.Driver.Flush(session(db), docType)
+}
+
+// Count 符合条件的文档总数
+func (s *Search) Count(q Query) (int64, error) {
+	if len(QueryTerms(q.Text)) == 0 {
+		return 0, nil
+	}
+	return s.Driver.Count(session(nil), q)
+}
+
+// Search 搜索，按相关度排序，使用 q.Offset 和 q.Limit 分页
+func (s *Search) Search(q Query) ([]Hit, error) {
+	if len(QueryTerms(q.Text)) == 0 {
+		return []Hit{}, nil
+	}
+	return s.Driver.Search(session(nil), q)
+}
+
+// session 返回一个全新的查询句柄，钩子中的 tx 带有当前语句的状态，不能直接使用
+func session(db *gorm.DB) *gorm.DB {
+	if db == nil {
+		db = database.DB
+	}
+	return db.Session(&gorm.Session{NewDB: true})
+}
+
+// applyFilters 数据库驱动共用的过滤条件，created_at 以时间戳保存
+func applyFilters(db *gorm.DB, q Query) *gorm.DB {
+	if len(q.Type) > 0 {
+		db = db.Where("doc_type = ?", q.Type)
+	}
+	if len(q.CategoryID) > 0 {
+		db = db.Where("category_id = ?", q.CategoryID)
+	}
+	if len(q.UserID) > 0 {
+		db = db.Where("user_id = ?", q.UserID)
+	}
+	if !q.From.IsZero() {
+		db = db.Where("created_at >= ?", q.From.Unix())
+	}
+	if !q.To.IsZero() {
+		db = db.Where("created_at < ?", q.To.Unix())
+	}
+	return db
+}
+
+// matchFilters 与 applyFilters 相同的过滤条件，供 memory 驱动使用
+func matchFilters(doc memoryDoc, q Query) bool {
+	if len(q.Type) > 0 && doc.Type != q.Type {
+		return false
+	}
+	if len(q.CategoryID) > 0 && doc.CategoryID != q.CategoryID {
+		return false
+	}
+	if len(q.UserID) > 0 && doc.UserID != q.UserID {
+		return false
+	}
+	if !q.From.IsZero() && doc.CreatedAt < q.From.Unix() {
+		return false
+	}
+	if !q.To.IsZero() && doc.CreatedAt >= q.To.Unix() {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"unicode"
+)
+
+// Tokenize 索引分词：英文和数字按单词切分并转为小写，
+// 中日韩文字没有空格分隔，切分为单字和相邻的两字（bigram）
+func Tokenize(text string) []string {
+	var terms []string
+	for _, seg := range segments(text) {
+		if !seg.cjk {
+			terms = append(terms, string(seg.runes))
+			continue
+		}
+		for i := range seg.runes {
+			terms = append(terms, string(seg.runes[i]))
+			if i+1 < len(seg.runes) {
+				terms = append(terms, string(seg.runes[i:i+2]))
+			}
+		}
+	}
+	return terms
+}
+
+// QueryTerms 查询分词，文档需匹配所有的词。中日韩文字使用 bigram，
+// 只有一个字时使用单字，与 Tokenize 的结果对应
+func QueryTerms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	for _, seg := range segments(text) {
+		if !seg.cjk || len(seg.runes) == 1 {
+			add(string(seg.runes))
+			continue
+		}
+		for i := 0; i+1 < len(seg.runes); i++ {
+			add(string(seg.runes[i : i+2]))
+		}
+	}
+	return terms
+}
+
+// segment 连续的单词或连续的中日韩文字
+type segment struct {
+	runes []rune
+	cjk   bool
+}
+
+// segments 按字符类型切分文本，标点和空白作为分隔符，英文转为小写
+func segments(text string) []segment {
+	var segs []segment
+	var current segment
+	flush := func() {
+		if len(current.runes) > 0 {
+			segs = append(segs, current)
+		}
+		current = segment{}
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			if !current.cjk {
+				flush()
+				current.cjk = true
+			}
+			current.runes = append(current.runes, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if current.cjk {
+				flush()
+			}
+			current.runes = append(current.runes, unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return segs
+}
+
+// isCJK 是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,142 @@
+package session
+
+import (
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/jwt"
+	"gohub/pkg/redis"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Session 单个登录会话（设备），会话 ID 即 JWT 的令牌家族 ID
+type Session struct {
+	ID         string `json:"id"`
+	UserID     string `json:"-"`
+	DeviceName string `json:"device_name"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	IssuedAt   int64  `json:"issued_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+// Registry 会话登记簿
+type Registry struct {
+	Store Store
+}
+
+// touchInterval 最后活跃时间的更新间隔，避免每个请求都写 Redis
+const touchInterval = time.Minute
+
+// once 确保 internalRegistry 对象只初始化一次
+var once sync.Once
+
+// internalRegistry 内部使用的 Registry 对象
+var internalRegistry *Registry
+
+// NewRegistry 单例模式获取
+func NewRegistry() *Registry {
+	once.Do(func() {
+		internalRegistry = &Registry{
+			Store: &RedisStore{
+				RedisClient: redis.Redis,
+				KeyPrefix:   config.GetString("app.name") + ":sessions:",
+				ExpireTime:  maxLifetime(),
+			},
+		}
+	})
+	return internalRegistry
+}
+
+// Register 登录成功后登记会话，调用示例：
+//         tokens := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+//         session.NewRegistry().Register(c, userModel.GetStringID(), tokens.FamilyID, request.DeviceName)
+func (r *Registry) Register(c *gin.Context, userID, sessionID, deviceName string) Session {
+	now := app.TimenowInTimezone()
+	if len(deviceName) == 0 {
+		deviceName = "未命名设备"
+	}
+	sess := Session{
+		ID:         sessionID,
+		UserID:     userID,
+		DeviceName: deviceName,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		IssuedAt:   now.Unix(),
+		LastSeenAt: now.Unix(),
+		ExpiresAt:  now.Add(maxLifetime()).Unix(),
+	}
+	r.Store.Save(sess)
+	return sess
+}
+
+// Touch 更新会话的最后活跃时间和 IP，会话不存在（已登出或已过期）时返回 false
+func (r *Registry) Touch(c *gin.Context, userID, sessionID string) bool {
+	sess, ok := r.Store.Get(userID, sessionID)
+	if !ok {
+		return false
+	}
+
+	now := app.TimenowInTimezone().Unix()
+	if sess.ExpiresAt < now {
+		r.Store.Delete(userID, sessionID)
+		return false
+	}
+
+	if now-sess.LastSeenAt >= int64(touchInterval.Seconds()) || sess.IP != c.ClientIP() {
+		sess.LastSeenAt = now
+		sess.IP = c.ClientIP()
+		r.Store.Save(sess)
+	}
+	return true
+}
+
+// List 获取用户所有有效的会话，按最后活跃时间倒序
+func (r *Registry) List(userID string) []Session {
+	now := app.TimenowInTimezone().Unix()
+	j := jwt.NewJWT()
+
+	sessions := []Session{}
+	for _, sess := range r.Store.All(userID) {
+		// 顺手清理已过期或已被吊销（如刷新令牌被重复使用）的会话
+		if sess.ExpiresAt < now || j.Store.IsFamilyRevoked(sess.ID) {
+			r.Store.Delete(userID, sess.ID)
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+
+	sort.Slice(sessions, func(i, k int) bool {
+		return sessions[i].LastSeenAt > sessions[k].LastSeenAt
+	})
+	return sessions
+}
+
+// Revoke 注销用户的某个会话，该会话下的所有令牌立即失效
+func (r *Registry) Revoke(userID, sessionID string) bool {
+	if _, ok := r.Store.Get(userID, sessionID); !ok {
+		return false
+	}
+	jwt.NewJWT().RevokeFamily(sessionID)
+	return r.Store.Delete(userID, sessionID)
+}
+
+// RevokeAll 注销用户的所有会话，返回注销的会话数量
+func (r *Registry) RevokeAll(userID string) int {
+	j := jwt.NewJWT()
+	sessions := r.Store.All(userID)
+	for _, sess := range sessions {
+		j.RevokeFamily(sess.ID)
+	}
+	r.Store.DeleteAll(userID)
+	return len(sessions)
+}
+
+// maxLifetime 会话的最长存活时间，与刷新令牌的最大刷新时间一致
+func maxLifetime() time.Duration {
+	return time.Duration(config.GetInt64("jwt.max_refresh_time")) * time.Minute
+}
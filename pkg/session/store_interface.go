@@ -0,0 +1,18 @@
+package session
+
+type Store interface {
+	// Save 保存会话，已存在则覆盖
+	Save(s Session) bool
+
+	// Get 获取用户的某个会话
+	Get(userID, sessionID string) (Session, bool)
+
+	// All 获取用户的所有会话
+	All(userID string) []Session
+
+	// Delete 删除用户的某个会话
+	Delete(userID, sessionID string) bool
+
+	// DeleteAll 删除用户的所有会话
+	DeleteAll(userID string) bool
+}
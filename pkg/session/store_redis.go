@@ -0,0 +1,78 @@
+package session
+
+import (
+	"encoding/json"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"time"
+)
+
+// RedisStore 实现 session.Store interface
+// 每个用户一个哈希表，field 为会话 ID，value 为会话的 JSON
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+	ExpireTime  time.Duration
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// Save 实现 session.Store interface 的 Save 方法
+func (s *RedisStore) Save(sess Session) bool {
+	b, err := json.Marshal(sess)
+	if err != nil {
+		logger.LogIf(err)
+		return false
+	}
+	key := s.storeKey(sess.UserID)
+	if ok := s.RedisClient.HSet(key, sess.ID, string(b)); !ok {
+		return false
+	}
+	// 最后一个会话过期后，整个哈希表随之过期
+	return s.RedisClient.Expire(key, s.ExpireTime)
+}
+
+// Get 实现 session.Store interface 的 Get 方法
+func (s *RedisStore) Get(userID, sessionID string) (Session, bool) {
+	val := s.RedisClient.HGet(s.storeKey(userID), sessionID)
+	if len(val) == 0 {
+		return Session{}, false
+	}
+	return s.decode(val)
+}
+
+// All 实现 session.Store interface 的 All 方法
+func (s *RedisStore) All(userID string) []Session {
+	var sessions []Session
+	for _, val := range s.RedisClient.HGetAll(s.storeKey(userID)) {
+		if sess, ok := s.decode(val); ok {
+			sessions = append(sessions, sess)
+		}
+	}
+	return sessions
+}
+
+// Delete 实现 session.Store interface 的 Delete 方法
+func (s *RedisStore) Delete(userID, sessionID string) bool {
+	return s.RedisClient.HDel(s.storeKey(userID), sessionID)
+}
+
+// DeleteAll 实现 session.Store interface 的 DeleteAll 方法
+func (s *RedisStore) DeleteAll(userID string) bool {
+	return s.RedisClient.Del(s.storeKey(userID))
+}
+
+// decode 解析会话 JSON
+func (s *RedisStore) decode(val string) (Session, bool) {
+	var sess Session
+	if err := json.Unmarshal([]byte(val), &sess); err != nil {
+		logger.LogIf(err)
+		return Session{}, false
+	}
+	return sess, true
+}
+
+// storeKey 获取 redis 保存的 key
+func (s *RedisStore) storeKey(userID string) string {
+	return s.KeyPrefix + userID
+}
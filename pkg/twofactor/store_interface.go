@@ -0,0 +1,20 @@
+package twofactor
+
+import "time"
+
+type Store interface {
+	// SaveChallenge 保存登录挑战，value 为挑战内容的 JSON
+	SaveChallenge(token, value string, expiration time.Duration) bool
+
+	// GetChallenge 获取登录挑战，不存在时返回空字符串
+	GetChallenge(token string) string
+
+	// DeleteChallenge 删除登录挑战
+	DeleteChallenge(token string) bool
+
+	// IncrementAttempts 记录一次验证失败，返回累计失败次数
+	IncrementAttempts(token string, expiration time.Duration) int64
+
+	// Claim 占用一次性的凭据（验证码的时间步、恢复码），已被占用时返回 false
+	Claim(key string, expiration time.Duration) bool
+}
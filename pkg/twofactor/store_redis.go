@@ -0,0 +1,46 @@
+package twofactor
+
+import (
+	"gohub/pkg/redis"
+	"time"
+)
+
+// RedisStore 实现 twofactor.Store interface
+type RedisStore struct {
+	RedisClient *redis.RedisClient
+	KeyPrefix   string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// SaveChallenge 实现 twofactor.Store interface 的 SaveChallenge 方法
+func (s *RedisStore) SaveChallenge(token, value string, expiration time.Duration) bool {
+	return s.RedisClient.Set(s.storeKey("challenge:"+token), value, expiration)
+}
+
+// GetChallenge 实现 twofactor.Store interface 的 GetChallenge 方法
+func (s *RedisStore) GetChallenge(token string) string {
+	return s.RedisClient.Get(s.storeKey("challenge:" + token))
+}
+
+// DeleteChallenge 实现 twofactor.Store interface 的 DeleteChallenge 方法
+func (s *RedisStore) DeleteChallenge(token string) bool {
+	s.RedisClient.Del(s.storeKey("attempts:" + token))
+	return s.RedisClient.Del(s.storeKey("challenge:" + token))
+}
+
+// IncrementAttempts 实现 twofactor.Store interface 的 IncrementAttempts 方法
+func (s *RedisStore) IncrementAttempts(token string, expiration time.Duration) int64 {
+	return s.RedisClient.IncrementWithExpire(s.storeKey("attempts:"+token), expiration)
+}
+
+// Claim 实现 twofactor.Store interface 的 Claim 方法
+func (s *RedisStore) Claim(key string, expiration time.Duration) bool {
+	ok, _ := s.RedisClient.SetNX(s.storeKey("used:"+key), 1, expiration)
+	return ok
+}
+
+// storeKey 获取 redis 保存的 key
+func (s *RedisStore) storeKey(key string) string {
+	return s.KeyPrefix + key
+}
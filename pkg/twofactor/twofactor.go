@@ -0,0 +1,205 @@
+// Package twofactor 两步验证，基于 TOTP 验证码与一次性恢复码
+package twofactor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"gohub/app/models/user"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"gohub/pkg/totp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+)
+
+// Challenge 密码验证通过后、等待输入验证码的登录挑战
+type Challenge struct {
+	UserID     string `json:"user_id"`
+	DeviceName string `json:"device_name"`
+}
+
+// TwoFactor 两步验证
+type TwoFactor struct {
+	Store Store
+}
+
+// once 确保 internalTwoFactor 对象只初始化一次
+var once sync.Once
+
+// internalTwoFactor 内部使用的 TwoFactor 对象
+var internalTwoFactor *TwoFactor
+
+// NewTwoFactor 单例模式获取
+func NewTwoFactor() *TwoFactor {
+	once.Do(func() {
+		internalTwoFactor = &TwoFactor{
+			Store: &RedisStore{
+				RedisClient: redis.Redis,
+				KeyPrefix:   config.GetString("app.name") + ":two_factor:",
+			},
+		}
+	})
+	return internalTwoFactor
+}
+
+// Enroll 为用户生成新的密钥，需调用 Confirm 验证后才会开启，返回密钥和 otpauth:// 链接
+func (tf *TwoFactor) Enroll(userModel *user.User) (string, string, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	userModel.TwoFactorSecret = secret
+	userModel.TwoFactorConfirmedAt = nil
+	userModel.TwoFactorRecoveryCodes = ""
+	userModel.Save()
+
+	account := userModel.Email
+	if len(account) == 0 {
+		account = userModel.Name
+	}
+	return secret, totp.ProvisioningURI(config.GetString("two_factor.issuer"), account, secret), nil
+}
+
+// Confirm 使用身份验证器的验证码确认开启，成功后返回恢复码明文（仅此一次）
+func (tf *TwoFactor) Confirm(userModel *user.User, code string) ([]string, bool) {
+	if len(userModel.TwoFactorSecret) == 0 || !tf.VerifyCode(userModel, code) {
+		return nil, false
+	}
+	now := app.TimenowInTimezone()
+	userModel.TwoFactorConfirmedAt = &now
+	return tf.RegenerateRecoveryCodes(userModel), true
+}
+
+// Disable 关闭两步验证
+func (tf *TwoFactor) Disable(userModel *user.User) bool {
+	userModel.TwoFactorSecret = ""
+	userModel.TwoFactorRecoveryCodes = ""
+	userModel.TwoFactorConfirmedAt = nil
+	return userModel.Save() > 0
+}
+
+// RegenerateRecoveryCodes 生成新的恢复码，旧恢复码全部作废，数据库只保存哈希值
+func (tf *TwoFactor) RegenerateRecoveryCodes(userModel *user.User) []string {
+	codes := make([]string, config.GetInt("two_factor.recovery_codes", 8))
+	hashes := make([]string, len(codes))
+	for i := range codes {
+		codes[i] = randomRecoveryCode()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	b, _ := json.Marshal(hashes)
+	userModel.TwoFactorRecoveryCodes = string(b)
+	userModel.Save()
+	return codes
+}
+
+// Verify 校验验证码或恢复码，6 位数字按验证码处理，其余按恢复码处理
+func (tf *TwoFactor) Verify(userModel *user.User, code string) bool {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return tf.VerifyCode(userModel, code)
+	}
+	return tf.UseRecoveryCode(userModel, code)
+}
+
+// VerifyCode 校验身份验证器的验证码，同一时间步的验证码只能使用一次
+func (tf *TwoFactor) VerifyCode(userModel *user.User, code string) bool {
+	step, ok := totp.Validate(userModel.TwoFactorSecret, code, time.Now(), config.GetInt("two_factor.skew", 1))
+	if !ok {
+		return false
+	}
+	// 占用到该时间步在容差范围内失效为止
+	ttl := time.Duration((2*config.GetInt("two_factor.skew", 1)+1)*totp.Period) * time.Second
+	return tf.Store.Claim(userModel.GetStringID()+":step:"+cast.ToString(step), ttl)
+}
+
+// UseRecoveryCode 使用恢复码，使用后即作废
+func (tf *TwoFactor) UseRecoveryCode(userModel *user.User, code string) bool {
+	var hashes []string
+	if err := json.Unmarshal([]byte(userModel.TwoFactorRecoveryCodes), &hashes); err != nil {
+		return false
+	}
+
+	hashed := hashRecoveryCode(code)
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hashed)) != 1 {
+			continue
+		}
+		// 防止并发请求重复使用同一个恢复码
+		if !tf.Store.Claim(userModel.GetStringID()+":recovery:"+hashed, time.Hour) {
+			return false
+		}
+		hashes = append(hashes[:i], hashes[i+1:]...)
+		b, _ := json.Marshal(hashes)
+		userModel.TwoFactorRecoveryCodes = string(b)
+		userModel.Save()
+		return true
+	}
+	return false
+}
+
+// CreateChallenge 密码验证通过后创建登录挑战，返回挑战令牌
+func (tf *TwoFactor) CreateChallenge(userID, deviceName string) string {
+	token := uuid.New().String()
+	b, _ := json.Marshal(Challenge{UserID: userID, DeviceName: deviceName})
+	tf.Store.SaveChallenge(token, string(b), ChallengeExpireTime())
+	return token
+}
+
+// GetChallenge 获取登录挑战
+func (tf *TwoFactor) GetChallenge(token string) (Challenge, bool) {
+	var challenge Challenge
+	val := tf.Store.GetChallenge(token)
+	if len(val) == 0 {
+		return challenge, false
+	}
+	if err := json.Unmarshal([]byte(val), &challenge); err != nil {
+		logger.LogIf(err)
+		return challenge, false
+	}
+	return challenge, true
+}
+
+// FailChallenge 记录一次验证失败，超过最大次数后挑战作废
+func (tf *TwoFactor) FailChallenge(token string) {
+	attempts := tf.Store.IncrementAttempts(token, ChallengeExpireTime())
+	if attempts >= config.GetInt64("two_factor.max_attempts", 5) {
+		tf.Store.DeleteChallenge(token)
+	}
+}
+
+// CompleteChallenge 登录成功，挑战令牌作废
+func (tf *TwoFactor) CompleteChallenge(token string) {
+	tf.Store.DeleteChallenge(token)
+}
+
+// ChallengeExpireTime 登录挑战的有效期
+func ChallengeExpireTime() time.Duration {
+	return time.Duration(config.GetInt64("two_factor.challenge_expire_time", 5)) * time.Minute
+}
+
+// randomRecoveryCode 生成形如 xxxxx-xxxxx 的恢复码
+func randomRecoveryCode() string {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		logger.LogIf(err)
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:]
+}
+
+// hashRecoveryCode 恢复码本身是高熵随机串，使用 SHA-256 即可，忽略大小写、空格和连字符
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -80,6 +80,27 @@ func (vc *VerifyCode) SendEmail(email string) error {
 	return nil
 }
 
+// SendEmailLink 发送 Email 验证链接，链接由 signedurl 签名，调用示例：
+//         verifycode.NewVerifyCode().SendEmailLink(userModel.Email, link)
+func (vc *VerifyCode) SendEmailLink(email, link string) bool {
+	logger.DebugJSON("验证码", "生成验证链接", map[string]string{email: link})
+	// 方便本地和 API 自动测试
+	if !app.IsProduction() && strings.HasSuffix(email, config.GetString("verifycode.debug_email_suffix")) {
+		return true
+	}
+
+	content := fmt.Sprintf(`<h1>请点击链接验证您的 Email</h1><p><a href="%[1]v">%[1]v</a></p>`, link)
+	return mail.NewMailer().Send(mail.Email{
+		From: mail.From{
+			Address: config.GetString("mail.from.address"),
+			Name:    config.GetString("mail.from.name"),
+		},
+		To:      []string{email},
+		Subject: "验证您的 Email",
+		HTML:    []byte(content),
+	})
+}
+
 // CheckAnswer 检查用户提交的验证码是否正确，key 可以是手机号或者 Email
 func (vc *VerifyCode) CheckAnswer(key, answer string) bool {
 	logger.DebugJSON("验证码", "检查验证码", map[string]string{key: answer})
@@ -4,19 +4,18 @@ import (
 	controllers "gohub/app/http/controllers/api/v1"
 	"gohub/app/http/controllers/api/v1/auth"
 	"gohub/app/http/middlewares"
+	"gohub/pkg/app"
 	"gohub/pkg/config"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterAPIRoutes(r *gin.Engine) {
-	// 支持 api 域名
-	var v1 *gin.RouterGroup
-	if len(config.Get("app.api_domain")) == 0 {
-		v1 = r.Group("/api/v1")
-	} else {
-		v1 = r.Group("v1")
-	}
+	// JWT 验签公钥，按照惯例放在站点根目录下
+	r.GET("/.well-known/jwks.json", new(auth.JWKSController).Show)
+
+	// 支持 api 域名，未设置时所有 API 加 api 前缀
+	v1 := r.Group(app.V1Prefix())
 
 	// 全局限流中间件：每小时限流。这里是所有 API （根据 IP）请求加起来。
 	// 作为参考 Github API 每小时最多 60 个请求（根据 IP）。
@@ -50,8 +49,33 @@ func RegisterAPIRoutes(r *gin.Engine) {
 			authGroup.POST("/login/using-phone", lc.LoginByPhone)
 			// 支持手机号，Email 和 用户名
 			authGroup.POST("/login/using-password", lc.LoginByPassword)
+			// 两步验证登录，使用密码登录返回的挑战令牌
+			authGroup.POST("/login/two-factor", middlewares.LimitPerRoute("30-H"), lc.LoginByTwoFactor)
+			// 登录失败次数过多被锁定时，使用手机或邮件验证码解锁
+			ulc := new(auth.UnlockController)
+			authGroup.POST("/login/unlock/using-phone", ulc.UnlockByPhone)
+			authGroup.POST("/login/unlock/using-email", ulc.UnlockByEmail)
+			// 第三方登录
+			oc := new(auth.OAuthController)
+			authGroup.GET("/oauth", oc.Providers)
+			authGroup.GET("/oauth/:provider/redirect", oc.Redirect)
+			authGroup.GET("/oauth/:provider/callback", middlewares.LimitPerRoute("60-H"), oc.Callback)
+			// 本地模拟的第三方平台授权页，仅在开启 oauth.fake.enabled 时可用
+			authGroup.GET("/oauth-fake/authorize", oc.FakeAuthorize)
 			// 刷新 token
 			authGroup.POST("/login/refresh-token", lc.RefreshToken)
+			// 退出登录
+			authGroup.POST("/logout", middlewares.AuthJWT(), middlewares.SessionOnly(), lc.Logout)
+			// 退出所有设备
+			authGroup.POST("/logout/all", middlewares.AuthJWT(), middlewares.SessionOnly(), lc.LogoutAll)
+			// 已登录的设备
+			sc := new(auth.SessionsController)
+			authGroup.GET("/sessions", middlewares.AuthJWT(), middlewares.SessionOnly(), sc.Index)
+			authGroup.DELETE("/sessions/:id", middlewares.AuthJWT(), middlewares.SessionOnly(), sc.Delete)
+			// Email 验证链接
+			evc := new(auth.EmailVerificationController)
+			authGroup.POST("/email/verification", middlewares.AuthJWT(), middlewares.LimitPerRoute("6-H"), evc.Send)
+			authGroup.GET("/email/verify", evc.Verify)
 			// 重置密码
 			pc := new(auth.PasswordController)
 			// 使用手机重置密码
@@ -63,39 +87,179 @@ func RegisterAPIRoutes(r *gin.Engine) {
 		uc := new(controllers.UsersController)
 		// 获取当前用户
 		v1.GET("/user", middlewares.AuthJWT(), uc.CurrentUser)
+		// 当前用户收藏的话题
+		fc := new(controllers.FavoritesController)
+		v1.GET("/user/favorites", middlewares.AuthJWT(), fc.Index)
+		// 当前用户的时间线
+		feedc := new(controllers.FeedController)
+		v1.GET("/user/feed", middlewares.AuthJWT(), feedc.Index)
+		// 当前用户的草稿和定时发布的话题
+		tc := new(controllers.TopicsController)
+		v1.GET("/user/drafts", middlewares.AuthJWT(), tc.Drafts)
+		// 当前用户的黑名单
+		bc := new(controllers.BlocksController)
+		v1.GET("/user/blocks", middlewares.AuthJWT(), bc.Index)
 		usersGroup := v1.Group("/users")
 		{
+			// 公开的用户列表不包含已删除的用户，回收站中的用户见 /trash/users
 			usersGroup.GET("", uc.Index)
+			usersGroup.DELETE("/:id", middlewares.AuthJWT(), middlewares.Permission("user.delete"), uc.Delete)
+			usersGroup.GET("/:id/topics", middlewares.AuthJWT(), middlewares.WithTrashed("topic.restore"), uc.Topics)
+			// 关注
+			flc := new(controllers.FollowsController)
+			usersGroup.GET("/:id/followers", middlewares.AuthJWT(), flc.Followers)
+			usersGroup.GET("/:id/followings", middlewares.AuthJWT(), flc.Followings)
+			usersGroup.PUT("/:id/follow", middlewares.AuthJWT(), flc.Store)
+			usersGroup.DELETE("/:id/follow", middlewares.AuthJWT(), flc.Delete)
+			// 屏蔽
+			usersGroup.PUT("/:id/block", middlewares.AuthJWT(), bc.Store)
+			usersGroup.DELETE("/:id/block", middlewares.AuthJWT(), bc.Delete)
 			usersGroup.PUT("", middlewares.AuthJWT(), uc.UpdateProfile)
-			usersGroup.PUT("/email", middlewares.AuthJWT(), uc.UpdateEmail)
-			usersGroup.PUT("/phone", middlewares.AuthJWT(), uc.UpdatePhone)
-			usersGroup.PUT("/password", middlewares.AuthJWT(), uc.UpdatePassword)
+			usersGroup.PUT("/email", middlewares.AuthJWT(), middlewares.SessionOnly(), uc.UpdateEmail)
+			usersGroup.PUT("/phone", middlewares.AuthJWT(), middlewares.SessionOnly(), uc.UpdatePhone)
+			usersGroup.PUT("/password", middlewares.AuthJWT(), middlewares.SessionOnly(), uc.UpdatePassword)
 			usersGroup.PUT("/avatar", middlewares.AuthJWT(), uc.UpdateAvatar)
+			// 绑定的第三方账号
+			uic := new(controllers.UserIdentitiesController)
+			usersGroup.GET("/identities", middlewares.AuthJWT(), uic.Index)
+			usersGroup.GET("/identities/:provider/redirect", middlewares.AuthJWT(), middlewares.SessionOnly(), uic.Redirect)
+			usersGroup.DELETE("/identities/:provider", middlewares.AuthJWT(), middlewares.SessionOnly(), uic.Delete)
+			// 个人访问令牌，令牌本身不能用于管理令牌
+			patc := new(controllers.PersonalAccessTokensController)
+			usersGroup.GET("/tokens", middlewares.AuthJWT(), middlewares.SessionOnly(), patc.Index)
+			usersGroup.POST("/tokens", middlewares.AuthJWT(), middlewares.SessionOnly(), patc.Store)
+			usersGroup.DELETE("/tokens/:id", middlewares.AuthJWT(), middlewares.SessionOnly(), patc.Delete)
+			// 两步验证
+			tfc := new(controllers.TwoFactorController)
+			usersGroup.POST("/two-factor", middlewares.AuthJWT(), middlewares.SessionOnly(), tfc.Store)
+			usersGroup.POST("/two-factor/confirm", middlewares.AuthJWT(), middlewares.SessionOnly(), tfc.Confirm)
+			usersGroup.DELETE("/two-factor", middlewares.AuthJWT(), middlewares.SessionOnly(), tfc.Delete)
+			usersGroup.POST("/two-factor/recovery-codes", middlewares.AuthJWT(), middlewares.SessionOnly(), tfc.RecoveryCodes)
 		}
 		// 分类
 		cc := new(controllers.CategoriesController)
 		ccGroup := v1.Group("/categories")
 		{
-			ccGroup.GET("", middlewares.AuthJWT(), cc.Index)
-			ccGroup.POST("", middlewares.AuthJWT(), cc.Store)
-			ccGroup.PUT("/:id", middlewares.AuthJWT(), cc.Update)
-			ccGroup.DELETE("/:id", middlewares.AuthJWT(), cc.Delete)
+			ccGroup.GET("", middlewares.AuthJWT(), middlewares.WithTrashed("category.restore"), cc.Index)
+			ccGroup.POST("", middlewares.AuthJWT(), middlewares.Permission("category.create"), cc.Store)
+			ccGroup.PUT("/:id", middlewares.AuthJWT(), middlewares.Permission("category.update"), cc.Update)
+			ccGroup.DELETE("/:id", middlewares.AuthJWT(), middlewares.Permission("category.delete"), cc.Delete)
+			ccGroup.GET("/:id/topics", middlewares.AuthJWT(), middlewares.WithTrashed("topic.restore"), cc.Topics)
 		}
 		// 话题
-		tc := new(controllers.TopicsController)
 		tcGroup := v1.Group("/topics")
 		{
-			tcGroup.POST("", middlewares.AuthJWT(), tc.Store)
+			tcGroup.POST("", middlewares.AuthJWT(), middlewares.Verified(), tc.Store)
 			tcGroup.PUT("/:id", middlewares.AuthJWT(), tc.Update)
 			tcGroup.DELETE("/:id", middlewares.AuthJWT(), tc.Delete)
-			tcGroup.GET("", middlewares.AuthJWT(), tc.Index)
-			tcGroup.GET("/:id", middlewares.AuthJWT(), tc.Show)
+			tcGroup.GET("", middlewares.AuthJWT(), middlewares.WithTrashed("topic.restore"), tc.Index)
+			tcGroup.GET("/:id", middlewares.AuthJWT(), middlewares.WithTrashed("topic.restore"), tc.Show)
+			// 发布草稿和定时发布
+			tcGroup.POST("/:id/publish", middlewares.AuthJWT(), middlewares.Verified(), tc.Publish)
+			tcGroup.PUT("/:id/schedule", middlewares.AuthJWT(), middlewares.Verified(), tc.Schedule)
+			tcGroup.DELETE("/:id/schedule", middlewares.AuthJWT(), tc.Unschedule)
+			// 置顶和锁定
+			tcGroup.PUT("/:id/pin", middlewares.AuthJWT(), middlewares.Permission("topic.pin"), tc.Pin)
+			tcGroup.DELETE("/:id/pin", middlewares.AuthJWT(), middlewares.Permission("topic.pin"), tc.Unpin)
+			tcGroup.PUT("/:id/lock", middlewares.AuthJWT(), middlewares.Permission("topic.lock"), tc.Lock)
+			tcGroup.DELETE("/:id/lock", middlewares.AuthJWT(), middlewares.Permission("topic.lock"), tc.Unlock)
+			// 修订历史和回滚
+			trc := new(controllers.TopicRevisionsController)
+			tcGroup.GET("/:id/revisions", middlewares.AuthJWT(), trc.Index)
+			tcGroup.GET("/:id/revisions/:version", middlewares.AuthJWT(), trc.Show)
+			tcGroup.POST("/:id/revisions/:version/rollback", middlewares.AuthJWT(), middlewares.Verified(), trc.Rollback)
+			// 回复
+			rc := new(controllers.RepliesController)
+			tcGroup.GET("/:id/replies", middlewares.AuthJWT(), rc.Index)
+			tcGroup.POST("/:id/replies", middlewares.AuthJWT(), middlewares.Verified(), rc.Store)
+			tcGroup.GET("/:id/replies/:reply_id", middlewares.AuthJWT(), rc.Show)
+			tcGroup.PUT("/:id/replies/:reply_id", middlewares.AuthJWT(), rc.Update)
+			tcGroup.DELETE("/:id/replies/:reply_id", middlewares.AuthJWT(), rc.Delete)
+			// 投票和收藏，PUT 和 DELETE 都是幂等的
+			vc := new(controllers.VotesController)
+			tcGroup.PUT("/:id/vote", middlewares.AuthJWT(), middlewares.Verified(), vc.Store)
+			tcGroup.DELETE("/:id/vote", middlewares.AuthJWT(), vc.Delete)
+			tcGroup.PUT("/:id/favorite", middlewares.AuthJWT(), fc.Store)
+			tcGroup.DELETE("/:id/favorite", middlewares.AuthJWT(), fc.Delete)
+			// 举报
+			rpc := new(controllers.ReportsController)
+			tcGroup.POST("/:id/report", middlewares.AuthJWT(), middlewares.Verified(), rpc.Store)
+		}
+		// 标签
+		tgc := new(controllers.TagsController)
+		// 分组上的 AuthJWT 同时为 WithTrashed 提供当前用户
+		tgGroup := v1.Group("/tags", middlewares.AuthJWT())
+		{
+			tgGroup.GET("", tgc.Index)
+			tgGroup.GET("/autocomplete", tgc.Autocomplete)
+			tgGroup.GET("/:slug", tgc.Show)
+			tgGroup.GET("/:slug/topics", middlewares.WithTrashed("topic.restore"), tgc.Topics)
+		}
+		// 举报处理
+		mc := new(controllers.ModerationController)
+		mcGroup := v1.Group("/moderation", middlewares.AuthJWT(), middlewares.Permission("report.moderate"))
+		{
+			mcGroup.GET("/reports", mc.Reports)
+			mcGroup.POST("/reports/:id/hide", mc.Hide)
+			mcGroup.POST("/reports/:id/resolve", mc.Resolve)
+			mcGroup.POST("/reports/:id/dismiss", mc.Dismiss)
+			mcGroup.GET("/logs", mc.Logs)
 		}
+		// 通知
+		nc := new(controllers.NotificationsController)
+		ncGroup := v1.Group("/notifications", middlewares.AuthJWT())
+		{
+			ncGroup.GET("", nc.Index)
+			ncGroup.GET("/unread-count", nc.UnreadCount)
+			ncGroup.PUT("/read-all", nc.MarkAllAsRead)
+			ncGroup.PUT("/:id/read", nc.MarkAsRead)
+			ncGroup.GET("/preferences", nc.Preferences)
+			ncGroup.PUT("/preferences", nc.UpdatePreference)
+		}
+		// 私信，发送私信按用户限流
+		cvc := new(controllers.ConversationsController)
+		cvGroup := v1.Group("/conversations", middlewares.AuthJWT())
+		{
+			cvGroup.GET("", cvc.Index)
+			cvGroup.GET("/unread-count", cvc.UnreadCount)
+			cvGroup.POST("", cvc.Store)
+			cvGroup.GET("/:id", cvc.Show)
+			cvGroup.GET("/:id/messages", cvc.Messages)
+			cvGroup.POST("/:id/messages", middlewares.Verified(), middlewares.LimitPerUser("message", config.GetString("message.send_limit")), cvc.StoreMessage)
+			cvGroup.PUT("/:id/read", cvc.MarkAsRead)
+		}
+		// 实时推送
+		rtc := new(controllers.RealtimeController)
+		rtGroup := v1.Group("/realtime", middlewares.TokenFromQuery(), middlewares.AuthJWT())
+		{
+			rtGroup.GET("/ws", rtc.WebSocket)
+			rtGroup.GET("/sse", rtc.SSE)
+		}
+		// 全文搜索
+		sc := new(controllers.SearchController)
+		v1.GET("/search", middlewares.AuthJWT(), middlewares.LimitPerRoute("300-H"), sc.Index)
 		// 友情链接
 		lc := new(controllers.LinksController)
 		lcGroup := v1.Group("/links")
 		{
 			lcGroup.GET("", lc.Index)
+			lcGroup.DELETE("/:id", middlewares.AuthJWT(), middlewares.Permission("link.delete"), lc.Delete)
+		}
+		// 回收站：已删除数据的列表、恢复和彻底删除
+		trashGroup := v1.Group("/trash", middlewares.AuthJWT())
+		{
+			trashGroup.GET("/topics", middlewares.Permission("topic.restore"), tc.Trashed)
+			trashGroup.POST("/topics/:id/restore", middlewares.Permission("topic.restore"), tc.Restore)
+			trashGroup.DELETE("/topics/:id", middlewares.Permission("topic.force_delete"), tc.ForceDelete)
+			trashGroup.GET("/categories", middlewares.Permission("category.restore"), cc.Trashed)
+			trashGroup.POST("/categories/:id/restore", middlewares.Permission("category.restore"), cc.Restore)
+			trashGroup.DELETE("/categories/:id", middlewares.Permission("category.force_delete"), cc.ForceDelete)
+			trashGroup.GET("/users", middlewares.Permission("user.restore"), uc.Trashed)
+			trashGroup.POST("/users/:id/restore", middlewares.Permission("user.restore"), uc.Restore)
+			trashGroup.DELETE("/users/:id", middlewares.Permission("user.force_delete"), uc.ForceDelete)
+			trashGroup.GET("/links", middlewares.Permission("link.restore"), lc.Trashed)
+			trashGroup.POST("/links/:id/restore", middlewares.Permission("link.restore"), lc.Restore)
+			trashGroup.DELETE("/links/:id", middlewares.Permission("link.force_delete"), lc.ForceDelete)
 		}
 	}
 }
@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"net/url"
+	"os"
+	"testing"
+
+	"gohub/app/http/controllers/api/v1/auth"
+	"gohub/app/models"
+	"gohub/app/models/user"
+	_ "gohub/config"
+	"gohub/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loadConfig 写入测试用的 .env.testing 并加载配置，测试结束后删除
+func loadConfig(t *testing.T, env string) {
+	if _, err := os.Stat(".env.testing"); err == nil {
+		t.Skip(".env.testing 已存在")
+	}
+	if err := os.WriteFile(".env.testing", []byte(env), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(".env.testing") })
+	config.InitConfig("testing")
+}
+
+func TestEmailVerificationLinkMatchesRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		env  string
+		path string
+	}{
+		{"默认带 api 前缀", "APP_ENV=testing\nAPP_URL=http://localhost:3000\n", "/api/v1/auth/email/verify"},
+		{"api 域名", "APP_ENV=testing\nAPP_URL=http://api.localhost:3000\nAPI_DOMAIN=api.localhost\n", "/v1/auth/email/verify"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadConfig(t, tt.env)
+
+			r := gin.New()
+			RegisterAPIRoutes(r)
+
+			link := auth.EmailVerificationLink(user.User{BaseModel: models.BaseModel{ID: 1}, Email: "summer@example.com"})
+			u, err := url.Parse(link)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Path != tt.path {
+				t.Errorf("链接路径为 %s，期望 %s", u.Path, tt.path)
+			}
+
+			found := false
+			for _, route := range r.Routes() {
+				if route.Method == "GET" && route.Path == u.Path {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("链接 %s 没有对应的路由", link)
+			}
+		})
+	}
+}